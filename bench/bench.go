@@ -0,0 +1,209 @@
+// Package bench times registered solvers and keeps a history of the results
+// so slowdowns between changes are easy to spot.
+package bench
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"runtime"
+	"runtime/metrics"
+	"testing"
+	"time"
+
+	"github.com/asc521/aoc-2022/ledger"
+	"github.com/asc521/aoc-2022/solver"
+)
+
+// DefaultHistoryPath is where the aoc command appends results.
+const DefaultHistoryPath = "bench_history.jsonl"
+
+// Result is the measurement of one part of one day.
+type Result struct {
+	Time        time.Time `json:"time"`
+	Day         int       `json:"day"`
+	Part        int       `json:"part"`
+	Input       string    `json:"input"`
+	N           int       `json:"n"`
+	NsPerOp     int64     `json:"ns_per_op"`
+	AllocsPerOp int64     `json:"allocs_per_op"`
+	BytesPerOp  int64     `json:"bytes_per_op"`
+	PeakHeap    uint64    `json:"peak_heap_bytes"`
+}
+
+// Measure benchmarks one part of s against input the way `go test -bench`
+// would, then makes a single extra run to sample the peak heap size.
+func Measure(s solver.Solver, day, part int, input []byte) (Result, error) {
+	if _, err := solver.Solve(s, part, bytes.NewReader(input)); err != nil {
+		return Result{}, err
+	}
+
+	br := testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			solver.Solve(s, part, bytes.NewReader(input))
+		}
+	})
+
+	return Result{
+		Time:        time.Now().UTC(),
+		Day:         day,
+		Part:        part,
+		Input:       ledger.Hash(input),
+		N:           br.N,
+		NsPerOp:     br.NsPerOp(),
+		AllocsPerOp: br.AllocsPerOp(),
+		BytesPerOp:  br.AllocedBytesPerOp(),
+		PeakHeap:    peakHeap(s, part, input),
+	}, nil
+}
+
+const heapObjects = "/memory/classes/heap/objects:bytes"
+
+// peakHeap reports how far live heap grew above its starting point during a
+// single solve. The heap is sampled, so very short peaks may be missed.
+func peakHeap(s solver.Solver, part int, input []byte) uint64 {
+	sample := []metrics.Sample{{Name: heapObjects}}
+	read := func() uint64 {
+		metrics.Read(sample)
+		if sample[0].Value.Kind() != metrics.KindUint64 {
+			return 0
+		}
+		return sample[0].Value.Uint64()
+	}
+
+	runtime.GC()
+	base := read()
+	peak := base
+
+	done := make(chan struct{})
+	sampled := make(chan uint64)
+	go func() {
+		p := base
+		ticker := time.NewTicker(100 * time.Microsecond)
+		defer ticker.Stop()
+		for {
+			if h := read(); h > p {
+				p = h
+			}
+			select {
+			case <-done:
+				sampled <- p
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	solver.Solve(s, part, bytes.NewReader(input))
+	if h := read(); h > peak {
+		peak = h
+	}
+	close(done)
+	if p := <-sampled; p > peak {
+		peak = p
+	}
+
+	return peak - base
+}
+
+// LoadHistory reads every result recorded at path. A missing file is an
+// empty history.
+func LoadHistory(path string) ([]Result, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var history []Result
+	lineNum := 0
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		lineNum++
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+
+		var r Result
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("%v:%v: %w", path, lineNum, err)
+		}
+		history = append(history, r)
+	}
+
+	return history, sc.Err()
+}
+
+// AppendHistory adds results to the history file at path, one JSON object
+// per line.
+func AppendHistory(path string, results []Result) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(f)
+	for _, r := range results {
+		if err := enc.Encode(r); err != nil {
+			f.Close()
+			return err
+		}
+	}
+
+	return f.Close()
+}
+
+// Previous returns the most recent result in history for the same day, part
+// and input as r.
+func Previous(history []Result, r Result) (Result, bool) {
+	for i := len(history) - 1; i >= 0; i-- {
+		h := history[i]
+		if h.Day == r.Day && h.Part == r.Part && h.Input == r.Input {
+			return h, true
+		}
+	}
+	return Result{}, false
+}
+
+// Change formats the ns/op difference between prev and r as a percentage.
+func Change(prev, r Result) string {
+	if prev.NsPerOp == 0 {
+		return "n/a"
+	}
+	delta := float64(r.NsPerOp-prev.NsPerOp) / float64(prev.NsPerOp) * 100
+	return fmt.Sprintf("%+.1f%%", delta)
+}
+
+// Markdown writes results as a table suitable for the README.
+func Markdown(w io.Writer, results []Result) {
+	fmt.Fprintln(w, "| Day | Part | ns/op | allocs/op | B/op | peak heap |")
+	fmt.Fprintln(w, "|----:|-----:|------:|----------:|-----:|----------:|")
+	for _, r := range results {
+		fmt.Fprintf(w, "| %v | %v | %v | %v | %v | %v |\n",
+			r.Day, r.Part, r.NsPerOp, r.AllocsPerOp, r.BytesPerOp, FormatBytes(r.PeakHeap))
+	}
+}
+
+// FormatBytes renders n using binary units.
+func FormatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%v B", n)
+	}
+
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
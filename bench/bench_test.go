@@ -0,0 +1,58 @@
+package bench
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+
+	first := []Result{
+		{Day: 8, Part: 1, Input: "abc", NsPerOp: 100},
+		{Day: 8, Part: 2, Input: "abc", NsPerOp: 300},
+	}
+	second := []Result{{Day: 8, Part: 1, Input: "abc", NsPerOp: 150}}
+	for _, rs := range [][]Result{first, second} {
+		if err := AppendHistory(path, rs); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	history, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("LoadHistory returned %v results, want 3", len(history))
+	}
+
+	current := Result{Day: 8, Part: 1, Input: "abc", NsPerOp: 120}
+	prev, ok := Previous(history, current)
+	if !ok || prev.NsPerOp != 150 {
+		t.Fatalf("Previous = %+v, %v; want the 150 ns/op run", prev, ok)
+	}
+	if got, want := Change(prev, current), "-20.0%"; got != want {
+		t.Errorf("Change = %q, want %q", got, want)
+	}
+
+	if _, ok := Previous(history, Result{Day: 8, Part: 1, Input: "other"}); ok {
+		t.Errorf("Previous matched a run on a different input")
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    uint64
+		want string
+	}{
+		{512, "512 B"},
+		{1536, "1.5 KiB"},
+		{3 << 20, "3.0 MiB"},
+	}
+	for _, tt := range tests {
+		if got := FormatBytes(tt.n); got != tt.want {
+			t.Errorf("FormatBytes(%v) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/asc521/aoc-2022/bench"
+	"github.com/asc521/aoc-2022/input"
+	"github.com/asc521/aoc-2022/solver"
+)
+
+func benchmark(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to benchmark (default every registered day)")
+	part := fs.Int("part", 0, "part to benchmark (default both)")
+	historyPath := fs.String("history", bench.DefaultHistoryPath, "file results are appended to; empty disables history")
+	markdown := fs.Bool("markdown", false, "print a markdown table for the README")
+	fs.Parse(args)
+
+	days := solver.Days()
+	if *day != 0 {
+		if _, ok := solver.Lookup(*day); !ok {
+			return fmt.Errorf("no solver registered for day %v (have %v)", *day, days)
+		}
+		days = []int{*day}
+	}
+
+	parts := []int{1, 2}
+	if *part != 0 {
+		parts = []int{*part}
+	}
+
+	var history []bench.Result
+	if *historyPath != "" {
+		var err error
+		history, err = bench.LoadHistory(*historyPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	resolver := input.NewResolver()
+	resolver.Stdin = nil
+
+	var results []bench.Result
+	for _, d := range days {
+		data, err := readInput(resolver, d)
+		if err != nil {
+			var notFound *input.NotFoundError
+			if errors.As(err, &notFound) {
+				fmt.Fprintf(os.Stderr, "day %v: skipped, no input\n", d)
+				continue
+			}
+			return err
+		}
+
+		s, _ := solver.Lookup(d)
+		for _, p := range parts {
+			r, err := bench.Measure(s, d, p, data)
+			if err != nil {
+				return fmt.Errorf("day %v part %v: %w", d, p, err)
+			}
+			results = append(results, r)
+		}
+	}
+
+	if *markdown {
+		bench.Markdown(os.Stdout, results)
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(w, "day\tpart\tns/op\tallocs/op\tB/op\tpeak heap\tvs last\t")
+		for _, r := range results {
+			change := "new"
+			if prev, ok := bench.Previous(history, r); ok {
+				change = bench.Change(prev, r)
+			}
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t\n",
+				r.Day, r.Part, r.NsPerOp, r.AllocsPerOp, r.BytesPerOp, bench.FormatBytes(r.PeakHeap), change)
+		}
+		w.Flush()
+	}
+
+	if *historyPath != "" {
+		return bench.AppendHistory(*historyPath, results)
+	}
+	return nil
+}
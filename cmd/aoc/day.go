@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/asc521/aoc-2022/input"
+	"github.com/asc521/aoc-2022/parse"
+	"github.com/asc521/aoc-2022/solver"
+)
+
+var dayCommandRX = regexp.MustCompile(`^day([0-9]+)$`)
+
+// dayCommand runs one of the extra commands a day registers, as in
+// "aoc day1 stats -stat p90".
+func dayCommand(day int, args []string) error {
+	if _, ok := solver.Lookup(day); !ok {
+		return fmt.Errorf("no solver registered for day %v (have %v)", day, solver.Days())
+	}
+
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "-help" {
+		return listCommands(os.Stdout, day)
+	}
+
+	c, ok := solver.LookupCommand(day, args[0])
+	if !ok {
+		listCommands(os.Stderr, day)
+		return fmt.Errorf("day %v has no command %q", day, args[0])
+	}
+
+	name := fmt.Sprintf("day%v %v", day, c.Name())
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	inputPath := fs.String("input", "", "path to the puzzle input, or - for stdin (default: $AOC_INPUT_DIR, then next to the binary, then stdin)")
+	logLevel := logFlag(fs)
+	c.SetFlags(fs)
+	fs.Parse(args[1:])
+
+	if err := setLogLevel(*logLevel); err != nil {
+		return err
+	}
+
+	src, err := input.Open(day, *inputPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	if err := c.Run(src, os.Stdout); err != nil {
+		var pe *parse.ParseError
+		if errors.As(err, &pe) {
+			return fmt.Errorf("%v: %w", src.Name, err)
+		}
+		return fmt.Errorf("%v: %w", name, err)
+	}
+	return nil
+}
+
+func listCommands(w io.Writer, day int) error {
+	cmds := solver.Commands(day)
+	if len(cmds) == 0 {
+		fmt.Fprintf(w, "day %v has no extra commands\n", day)
+		return nil
+	}
+
+	fmt.Fprintf(w, "usage: aoc day%v <command> [flags]\n\ncommands:\n", day)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, c := range cmds {
+		fmt.Fprintf(tw, "  %v\t%v\n", c.Name(), c.Synopsis())
+	}
+	return tw.Flush()
+}
+
+// parseDayCommand reports whether arg names a day, as in "day12".
+func parseDayCommand(arg string) (int, bool) {
+	m := dayCommandRX.FindStringSubmatch(arg)
+	if m == nil {
+		return 0, false
+	}
+	day, err := strconv.Atoi(m[1])
+	return day, err == nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/asc521/aoc-2022/fetch"
+)
+
+func fetchDay(args []string) error {
+	fs := flag.NewFlagSet("fetch", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to fetch (1-25)")
+	configPath := fs.String("config", "", "config file with the session cookie (default: aoc-2022/config.json in the user config directory)")
+	out := fs.String("out", ".", "directory to write dayN/input.txt and dayN/doc.go under")
+	offline := fs.Bool("offline", false, "only use the cache, never the network")
+	force := fs.Bool("force", false, "overwrite existing files")
+	fs.Parse(args)
+
+	if *day < 1 || *day > 25 {
+		return fmt.Errorf("fetch: -day must be between 1 and 25, got %v", *day)
+	}
+
+	if *configPath == "" {
+		path, err := fetch.DefaultConfigPath()
+		if err != nil {
+			return err
+		}
+		*configPath = path
+	}
+
+	config, err := fetch.LoadConfig(*configPath)
+	if err != nil {
+		return err
+	}
+
+	var next fetch.Fetcher
+	if !*offline {
+		next = &fetch.HTTPFetcher{
+			BaseURL: config.BaseURL,
+			Session: config.Session,
+			Limiter: fetch.NewLimiter(fetch.DefaultInterval, filepath.Join(config.CacheDir, "last_request")),
+		}
+	}
+
+	cache, err := fetch.OpenCache(config.CacheDir, next)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	dir := filepath.Join(*out, fmt.Sprintf("day%v", *day))
+
+	data, err := cache.Fetch(ctx, *day, fetch.Input)
+	if err != nil {
+		return err
+	}
+	if err := writeNew(filepath.Join(dir, "input.txt"), data, *force); err != nil {
+		return err
+	}
+
+	page, err := cache.Fetch(ctx, *day, fetch.Description)
+	if err != nil {
+		return err
+	}
+	doc := fetch.DocFile(*day, fetch.DescriptionText(page))
+	return writeNew(filepath.Join(dir, "doc.go"), []byte(doc), *force)
+}
+
+// writeNew writes data to path unless the file already exists and force is
+// not set.
+func writeNew(path string, data []byte, force bool) error {
+	if !force {
+		if _, err := os.Stat(path); err == nil {
+			fmt.Fprintf(os.Stderr, "fetch: %v exists, skipping (use -force to overwrite)\n", path)
+			return nil
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "fetch: wrote %v\n", path)
+	return nil
+}
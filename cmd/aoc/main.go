@@ -0,0 +1,98 @@
+// Command aoc runs any registered day and part of the Advent of Code 2022
+// solutions.
+//
+// Usage:
+//
+//	aoc run -day 5 -part 1 -input day5/input.txt
+//	aoc run -day 5 -part 1 -accept
+//	aoc run -day 9 -part 0 -format json -log debug
+//	aoc verify
+//	aoc bench -markdown
+//	aoc fetch -day 6
+//	aoc day1 stats -stat p90
+//
+// Without -input the puzzle input is looked up in $AOC_INPUT_DIR/dayN,
+// then in dayN next to the binary, then read from stdin.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	_ "github.com/asc521/aoc-2022/day1"
+	_ "github.com/asc521/aoc-2022/day10"
+	_ "github.com/asc521/aoc-2022/day11"
+	_ "github.com/asc521/aoc-2022/day12"
+	_ "github.com/asc521/aoc-2022/day13"
+	_ "github.com/asc521/aoc-2022/day2"
+	_ "github.com/asc521/aoc-2022/day3"
+	_ "github.com/asc521/aoc-2022/day4"
+	_ "github.com/asc521/aoc-2022/day5"
+	_ "github.com/asc521/aoc-2022/day6"
+	_ "github.com/asc521/aoc-2022/day7"
+	_ "github.com/asc521/aoc-2022/day8"
+	_ "github.com/asc521/aoc-2022/day9"
+	"github.com/asc521/aoc-2022/parse"
+)
+
+const usage = `usage: aoc <command> [flags]
+
+commands:
+  run     solve one part of one day and check it against the answer ledger
+  verify  re-run every day and part and compare with the answer ledger
+  bench   time every day and part and record the results
+  fetch   download a day's input and description through the local cache
+  dayN    run one of day N's extra commands; "aoc dayN" lists them
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "run":
+		err = run(os.Args[2:])
+	case "verify":
+		err = verify(os.Args[2:])
+	case "bench":
+		err = benchmark(os.Args[2:])
+	case "fetch":
+		err = fetchDay(os.Args[2:])
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(os.Stdout, usage)
+		return
+	default:
+		if day, ok := parseDayCommand(os.Args[1]); ok {
+			err = dayCommand(day, os.Args[2:])
+			break
+		}
+		fmt.Fprintf(os.Stderr, "aoc: unknown command %q\n\n%v", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		printError(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// printError reports err, showing malformed input with a caret under the
+// offending column.
+func printError(w io.Writer, err error) {
+	fmt.Fprintf(w, "aoc: %v\n", err)
+
+	var pe *parse.ParseError
+	if errors.As(err, &pe) && pe.Text != "" {
+		for _, line := range strings.SplitAfter(pe.Caret(), "\n") {
+			if line != "" {
+				fmt.Fprintf(w, "    %v", line)
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/asc521/aoc-2022/logger"
+)
+
+// Output formats for -format.
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+// result is one solved part as written by -format json, one object per line.
+type result struct {
+	Day    int    `json:"day"`
+	Part   int    `json:"part"`
+	Answer string `json:"answer"`
+	// Duration is in nanoseconds.
+	Duration time.Duration  `json:"duration"`
+	Meta     map[string]any `json:"meta,omitempty"`
+}
+
+func formatFlag(fs *flag.FlagSet) *string {
+	return fs.String("format", formatText, "output format: text, or json for one object per part")
+}
+
+func logFlag(fs *flag.FlagSet) *string {
+	return fs.String("log", logger.Off.String(), "diagnostics written to stderr: off, info, debug or trace")
+}
+
+// setup checks the -format value and applies the -log level.
+func setup(format, level string) error {
+	if format != formatText && format != formatJSON {
+		return fmt.Errorf("unknown format %q (want %v or %v)", format, formatText, formatJSON)
+	}
+
+	return setLogLevel(level)
+}
+
+// setLogLevel applies a -log value.
+func setLogLevel(level string) error {
+	l, err := logger.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	logger.SetLevel(l)
+	return nil
+}
+
+// emitter writes results in the chosen format.
+type emitter struct {
+	w   io.Writer
+	enc *json.Encoder
+}
+
+func newEmitter(w io.Writer, format string) *emitter {
+	e := &emitter{w: w}
+	if format == formatJSON {
+		e.enc = json.NewEncoder(w)
+	}
+	return e
+}
+
+func (e *emitter) emit(r result) error {
+	if e.enc != nil {
+		return e.enc.Encode(r)
+	}
+	_, err := fmt.Fprintln(e.w, r.Answer)
+	return err
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/asc521/aoc-2022/input"
+	"github.com/asc521/aoc-2022/ledger"
+	"github.com/asc521/aoc-2022/parse"
+	"github.com/asc521/aoc-2022/solver"
+)
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to solve (1-25)")
+	part := fs.Int("part", 1, "part to solve (1 or 2, or 0 for both)")
+	inputPath := fs.String("input", "", "path to the puzzle input, or - for stdin (default: $AOC_INPUT_DIR, then next to the binary, then stdin)")
+	answersPath := fs.String("answers", ledger.DefaultPath, "answer ledger to check against")
+	accept := fs.Bool("accept", false, "record this answer as the accepted one")
+	format := formatFlag(fs)
+	logLevel := logFlag(fs)
+	fs.Parse(args)
+
+	if err := setup(*format, *logLevel); err != nil {
+		return err
+	}
+
+	s, ok := solver.Lookup(*day)
+	if !ok {
+		return fmt.Errorf("no solver registered for day %v (have %v)", *day, solver.Days())
+	}
+
+	parts := []int{*part}
+	if *part == 0 {
+		parts = []int{1, 2}
+	}
+
+	src, err := input.Open(*day, *inputPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return fmt.Errorf("reading %v: %w", src.Name, err)
+	}
+
+	answers, err := ledger.Load(*answersPath)
+	if err != nil {
+		return fmt.Errorf("loading %v: %w", *answersPath, err)
+	}
+
+	hash := ledger.Hash(data)
+	out := newEmitter(os.Stdout, *format)
+
+	var mismatch error
+	for _, p := range parts {
+		start := time.Now()
+		answer, err := solver.Solve(s, p, bytes.NewReader(data))
+		elapsed := time.Since(start)
+		if err != nil {
+			var pe *parse.ParseError
+			if errors.As(err, &pe) {
+				return fmt.Errorf("%v: %w", src.Name, err)
+			}
+			return fmt.Errorf("day %v part %v: %w", *day, p, err)
+		}
+
+		meta := map[string]any{"input": src.Name, "input_sha256": hash}
+
+		if *accept {
+			answers.Accept(*day, p, hash, answer)
+			meta["ledger"] = "accepted"
+		} else {
+			status := answers.Check(*day, p, hash, answer)
+			meta["ledger"] = string(status)
+			if status == ledger.Mismatch {
+				accepted, _ := answers.Lookup(*day, p, hash)
+				mismatch = fmt.Errorf("day %v part %v: answer does not match accepted answer %q", *day, p, accepted)
+			}
+		}
+
+		if err := out.emit(result{Day: *day, Part: p, Answer: answer, Duration: elapsed, Meta: meta}); err != nil {
+			return err
+		}
+		if *format == formatText {
+			fmt.Fprintf(os.Stderr, "ledger: %v\n", meta["ledger"])
+		}
+	}
+
+	if *accept {
+		if err := answers.Save(); err != nil {
+			return fmt.Errorf("saving %v: %w", *answersPath, err)
+		}
+	}
+
+	return mismatch
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/asc521/aoc-2022/input"
+	"github.com/asc521/aoc-2022/ledger"
+	"github.com/asc521/aoc-2022/solver"
+)
+
+// verdicts printed in the verify matrix.
+const (
+	verdictPass    = "pass"
+	verdictFail    = "FAIL"
+	verdictUnknown = "unknown"
+	verdictNoInput = "no input"
+	verdictError   = "error"
+)
+
+func verify(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	answersPath := fs.String("answers", ledger.DefaultPath, "answer ledger to check against")
+	format := formatFlag(fs)
+	logLevel := logFlag(fs)
+	fs.Parse(args)
+
+	if err := setup(*format, *logLevel); err != nil {
+		return err
+	}
+
+	answers, err := ledger.Load(*answersPath)
+	if err != nil {
+		return fmt.Errorf("loading %v: %w", *answersPath, err)
+	}
+
+	// Every day reading the same stdin makes no sense, so only files are
+	// considered.
+	resolver := input.NewResolver()
+	resolver.Stdin = nil
+
+	var failures int
+	out := newEmitter(os.Stdout, *format)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if *format == formatText {
+		fmt.Fprintln(w, "day\tpart 1\tpart 2\t")
+	}
+	for _, day := range solver.Days() {
+		s, _ := solver.Lookup(day)
+		verdicts := [2]string{verdictNoInput, verdictNoInput}
+
+		results := [2]result{{Day: day, Part: 1}, {Day: day, Part: 2}}
+
+		data, err := readInput(resolver, day)
+		if err == nil {
+			hash := ledger.Hash(data)
+			for i, part := range []int{1, 2} {
+				results[i].Meta = map[string]any{"input_sha256": hash}
+
+				start := time.Now()
+				answer, err := solver.Solve(s, part, bytes.NewReader(data))
+				results[i].Duration = time.Since(start)
+				if err != nil {
+					printError(os.Stderr, fmt.Errorf("day %v part %v: %w", day, part, err))
+					verdicts[i] = verdictError
+					results[i].Meta["error"] = err.Error()
+					failures++
+					continue
+				}
+				results[i].Answer = answer
+
+				switch answers.Check(day, part, hash, answer) {
+				case ledger.Match:
+					verdicts[i] = verdictPass
+				case ledger.Mismatch:
+					verdicts[i] = verdictFail
+					failures++
+				case ledger.Unknown:
+					verdicts[i] = verdictUnknown
+				}
+			}
+		} else {
+			var notFound *input.NotFoundError
+			if !errors.As(err, &notFound) {
+				return err
+			}
+		}
+
+		if *format == formatJSON {
+			for i := range results {
+				if results[i].Meta == nil {
+					results[i].Meta = map[string]any{}
+				}
+				results[i].Meta["verdict"] = verdicts[i]
+				if err := out.emit(results[i]); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		fmt.Fprintf(w, "%v\t%v\t%v\t\n", day, verdicts[0], verdicts[1])
+	}
+	w.Flush()
+
+	if failures > 0 {
+		return fmt.Errorf("%v part(s) failed verification", failures)
+	}
+	return nil
+}
+
+func readInput(r *input.Resolver, day int) ([]byte, error) {
+	src, err := r.Open(day, "")
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	return io.ReadAll(src)
+}
@@ -1,11 +1,11 @@
-package main
+package day1
 
 import (
-	"bufio"
 	"fmt"
-	"os"
-	"sort"
+	"io"
 	"strconv"
+
+	"github.com/asc521/aoc-2022/solver"
 )
 
 /*
@@ -53,42 +53,35 @@ Find the Elf carrying the most Calories. How many total Calories is that Elf car
 https://adventofcode.com/2022/day/1
 */
 
-func main() {
+func init() {
+	solver.Register(1, Solver{})
+}
+
+// Solver answers day 1 from a calorie inventory.
+type Solver struct{}
 
-	file, err := os.Open("day1/input.txt")
+func (Solver) PartOne(r io.Reader) (string, error) {
+	top, err := TopK(r, 1)
 	if err != nil {
-		panic(err)
+		return "", err
 	}
-	defer file.Close()
-
-	var calorieCounts []int
-	var currentCount int
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-
-		line := scanner.Text()
-		if line == "" {
-			calorieCounts = append(calorieCounts, currentCount)
-			currentCount = 0
-			continue
-		}
-
-		calories, err := strconv.Atoi(line)
-		if err != nil {
-			panic(err)
-		}
-		currentCount += calories
 
+	return strconv.Itoa(top[0].Calories), nil
+}
+
+func (Solver) PartTwo(r io.Reader) (string, error) {
+	top, err := TopK(r, 3)
+	if err != nil {
+		return "", err
 	}
 
-	sort.Ints(calorieCounts)
-	fmt.Println(calorieCounts)
-	largest := calorieCounts[len(calorieCounts)-1]
-	fmt.Printf("Most Calories: %v\n", largest)
-	largestThreeCalories := 0
-	for _, c := range calorieCounts[len(calorieCounts)-3:] {
-		largestThreeCalories += c
+	if len(top) < 3 {
+		return "", fmt.Errorf("day1: need at least 3 elves, got %v", len(top))
 	}
-	fmt.Printf("Largest 3 Calories: %v\n", largestThreeCalories)
 
+	largestThreeCalories := 0
+	for _, e := range top {
+		largestThreeCalories += e.Calories
+	}
+	return strconv.Itoa(largestThreeCalories), nil
 }
@@ -0,0 +1,280 @@
+package day1
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asc521/aoc-2022/parse"
+	"github.com/asc521/aoc-2022/solver"
+	"github.com/asc521/aoc-2022/solver/solvertest"
+)
+
+func TestSolver(t *testing.T) {
+	solvertest.Run(t, Solver{}, []solvertest.Case{
+		{Name: "example part 1", File: "calorie_inventory.txt", Part: 1, Want: "24000"},
+		{Name: "example part 2", File: "calorie_inventory.txt", Part: 2, Want: "45000"},
+	})
+}
+
+func TestTopK(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		k     int
+		want  []Ranked
+	}{
+		{"example", example, 3, []Ranked{{4, 24000}, {3, 11000}, {5, 10000}}},
+		{"last group counts", "1\n\n2\n3", 1, []Ranked{{2, 5}}},
+		{"ties keep inventory order", "5\n\n7\n\n5\n", 3, []Ranked{{2, 7}, {1, 5}, {3, 5}}},
+		{"fewer elves than k", "1\n\n2\n", 5, []Ranked{{2, 2}, {1, 1}}},
+		{"csv ids", "elf,item_calories\n7,100\n12,300\n3,200\n7,50\n", 2, []Ranked{{12, 300}, {3, 200}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TopK(strings.NewReader(tt.input), tt.k)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TopK(%v) = %v, want %v", tt.k, got, tt.want)
+			}
+		})
+	}
+}
+
+const example = "1000\n2000\n3000\n\n4000\n\n5000\n6000\n\n7000\n8000\n9000\n\n10000\n"
+
+func TestStats(t *testing.T) {
+	elves, err := ReadElves(strings.NewReader(example))
+	if err != nil {
+		t.Fatal(err)
+	}
+	totals := Totals(elves)
+
+	if got := Mean(totals); got != 11000 {
+		t.Errorf("Mean = %v, want 11000", got)
+	}
+	if got := Median(totals); got != 10000 {
+		t.Errorf("Median = %v, want 10000", got)
+	}
+	if got := Percentile(totals, 90); got != 18800 {
+		t.Errorf("Percentile(90) = %v, want 18800", got)
+	}
+	if got, want := ItemHistogram(elves), map[int]int{1: 2, 2: 1, 3: 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ItemHistogram = %v, want %v", got, want)
+	}
+	if got := Outliers(elves); len(got) != 1 || got[0].Index != 4 {
+		t.Errorf("Outliers = %v, want elf 4", got)
+	}
+}
+
+func TestStatsCommand(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-stat", "p90"}, "18800\n"},
+		{[]string{"-stat", "most-items"}, "1\n"},
+		{[]string{"-over", "10000"}, "2\n"},
+		{[]string{"-elf", "3"}, "elf 3: 2 items, 11000 calories, rank 2 of 5\nitems: 5000 6000\n"},
+	}
+	for _, tt := range tests {
+		c := &statsCommand{}
+		fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+		c.SetFlags(fs)
+		if err := fs.Parse(tt.args); err != nil {
+			t.Fatal(err)
+		}
+
+		var out bytes.Buffer
+		if err := c.Run(strings.NewReader(example), &out); err != nil {
+			t.Errorf("%v: %v", tt.args, err)
+			continue
+		}
+		if out.String() != tt.want {
+			t.Errorf("%v: got %q, want %q", tt.args, out.String(), tt.want)
+		}
+	}
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	elves, err := ReadElves(strings.NewReader(example))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range []Format{Text, CSV, JSON} {
+		t.Run(string(f), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteElves(&buf, elves, f); err != nil {
+				t.Fatal(err)
+			}
+			if f == Text && buf.String() != example {
+				t.Errorf("text export = %q, want %q", buf.String(), example)
+			}
+
+			got, err := ReadElves(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, elves) {
+				t.Errorf("round trip through %v = %v, want %v", f, got, elves)
+			}
+		})
+	}
+}
+
+func TestFormatDetection(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"csv without header", "1,1000\n1,2000\n1,3000\n2,4000\n3,5000\n3,6000\n4,7000\n4,8000\n4,9000\n5,10000\n"},
+		{"csv with interleaved elves", "elf,item_calories\n1,1000\n2,4000\n3,5000\n1,2000\n4,7000\n3,6000\n4,8000\n1,3000\n4,9000\n5,10000\n"},
+		{"json", `[[1000,2000,3000],[4000],[5000,6000],[7000,8000,9000],[10000]]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for part, want := range map[int]string{1: "24000", 2: "45000"} {
+				got, err := solver.Solve(Solver{}, part, strings.NewReader(tt.input))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != want {
+					t.Errorf("part %v = %v, want %v", part, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRebalance(t *testing.T) {
+	elves, err := ReadElves(strings.NewReader(example))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		method Method
+		want   int
+	}{
+		{Auto, 11000},
+		{Exact, 11000},
+		{Greedy, 11000},
+	}
+	for _, tt := range tests {
+		plan, err := Rebalance(elves, tt.method)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if plan.Before != 24000 || plan.After != tt.want {
+			t.Errorf("%v: largest load %v -> %v, want 24000 -> %v", tt.method, plan.Before, plan.After, tt.want)
+		}
+
+		// Replaying the moves must give the planned loads.
+		loads := Totals(elves)
+		for _, m := range plan.Moves {
+			loads[m.From-1] -= m.Calories
+			loads[m.To-1] += m.Calories
+		}
+		if !reflect.DeepEqual(loads, plan.Loads) {
+			t.Errorf("%v: moves give loads %v, plan says %v", tt.method, loads, plan.Loads)
+		}
+	}
+
+	// Greedy puts 3, 3 | 2, 2, 2 for a largest load of 7; the optimum is 6.
+	uneven := []Elf{{Index: 1, Items: []int{3, 3, 2, 2, 2}}, {Index: 2}}
+	for method, want := range map[Method]int{Greedy: 7, Exact: 6} {
+		plan, err := Rebalance(uneven, method)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if plan.After != want {
+			t.Errorf("%v on %v: largest load %v, want %v", method, uneven, plan.After, want)
+		}
+	}
+}
+
+func TestRebalanceBudget(t *testing.T) {
+	// 24 items over 8 elves is within ExactLimit but far too many
+	// partitions to prove optimal, so Auto has to give up for Greedy.
+	elves := make([]Elf, 8)
+	for i := range elves {
+		elves[i] = Elf{Index: i + 1}
+	}
+	for i := 0; i < 24; i++ {
+		elves[i%8].Items = append(elves[i%8].Items, 1000+i*i*37%997)
+	}
+
+	done := make(chan Plan, 1)
+	go func() {
+		plan, err := Rebalance(elves, Auto)
+		if err != nil {
+			t.Error(err)
+		}
+		done <- plan
+	}()
+
+	select {
+	case plan := <-done:
+		greedy, err := Rebalance(elves, Greedy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if plan.After > greedy.After {
+			t.Errorf("auto: largest load %v, worse than greedy's %v", plan.After, greedy.After)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("auto rebalance of 24 items over 8 elves did not return")
+	}
+}
+
+func TestCSVErrors(t *testing.T) {
+	tests := []struct {
+		input     string
+		line, col int
+	}{
+		{"elf,item_calories\n1,100\nx,200\n", 3, 1},
+		{"1,100\n 01,200\n", 2, 2},
+		{"1,100\n0,200\n", 2, 1},
+		{"1,100\n2,lots\n", 2, 3},
+		{"1,100\n2,200,300\n", 2, 1},
+	}
+	for _, tt := range tests {
+		_, err := ReadElves(strings.NewReader(tt.input))
+		var pe *parse.ParseError
+		if !errors.As(err, &pe) || pe.Line != tt.line || pe.Col != tt.col || pe.Text == "" {
+			t.Errorf("%q: got %v, want a parse error with text at %v:%v", tt.input, err, tt.line, tt.col)
+		}
+	}
+}
+
+func TestRebalanceNeverWorse(t *testing.T) {
+	tests := []struct {
+		name  string
+		elves []Elf
+		after int
+		moves int
+	}{
+		// Greedy puts 3, 2 | 3, 2, 2 for 7, worse than the current 6.
+		{"greedy worse", []Elf{{Index: 1, Items: []int{3, 3}}, {Index: 2, Items: []int{2, 2, 2}}}, 6, 0},
+		// Any 4 will do, so only one changes hands.
+		{"equal items stay", []Elf{{Index: 1, Items: []int{4, 4, 4}}, {Index: 2, Items: []int{4}}}, 8, 1},
+	}
+	for _, tt := range tests {
+		for _, method := range []Method{Greedy, Auto} {
+			plan, err := Rebalance(tt.elves, method)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if plan.After > plan.Before || plan.After != tt.after || len(plan.Moves) != tt.moves {
+				t.Errorf("%v, %v: largest load %v -> %v with %v moves, want %v with %v", tt.name, method, plan.Before, plan.After, len(plan.Moves), tt.after, tt.moves)
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package day1
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/asc521/aoc-2022/parse"
+)
+
+// Elf is one elf's inventory. Index identifies the elf: its id in a CSV
+// inventory, otherwise its 1-based position. Items are the calories of each
+// item it carries.
+type Elf struct {
+	Index int
+	Items []int
+}
+
+// Total returns the calories the elf carries.
+func (e Elf) Total() int {
+	total := 0
+	for _, c := range e.Items {
+		total += c
+	}
+	return total
+}
+
+// ReadElves reads every elf in an inventory of any Format.
+func ReadElves(r io.Reader) ([]Elf, error) {
+	elves := []Elf{}
+	err := EachElf(r, func(e Elf) error {
+		elves = append(elves, e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(elves) == 0 {
+		return nil, fmt.Errorf("day1: inventory has no elves")
+	}
+	return elves, nil
+}
+
+func parseElf(g parse.Group, index int) (Elf, error) {
+	e := Elf{Index: index, Items: make([]int, 0, len(g.Lines))}
+	for i, line := range g.Lines {
+		calories, err := strconv.Atoi(line)
+		if err != nil {
+			return Elf{}, &parse.ParseError{Day: 1, Line: g.Line + i, Col: 1, Text: line, Cause: err}
+		}
+		e.Items = append(e.Items, calories)
+	}
+	return e, nil
+}
@@ -0,0 +1,256 @@
+package day1
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/asc521/aoc-2022/parse"
+	"github.com/asc521/aoc-2022/solver"
+)
+
+// Format is a way of writing down a calorie inventory.
+type Format string
+
+const (
+	// Text is the puzzle's format: one item per line, elves separated by
+	// a blank line.
+	Text = Format("text")
+	// CSV has a row per item, "elf,item_calories", under an optional
+	// header. Elf ids are positive integers kept as each Elf's Index, and
+	// elves are listed in order of first appearance.
+	CSV = Format("csv")
+	// JSON is an array holding an array of item calories per elf.
+	JSON = Format("json")
+)
+
+// csvHeader is written on export and skipped on import.
+var csvHeader = []string{"elf", "item_calories"}
+
+// ParseFormat returns the format called name.
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(name); f {
+	case Text, CSV, JSON:
+		return f, nil
+	}
+	return "", fmt.Errorf("day1: unknown format %q (want %v, %v or %v)", name, Text, CSV, JSON)
+}
+
+// detectFormat guesses the format from the start of br without consuming
+// it: a leading '[' is JSON, a comma on the first line is CSV, anything else
+// is text.
+func detectFormat(br *bufio.Reader) Format {
+	head, _ := br.Peek(4096)
+
+	trimmed := bytes.TrimLeft(head, " \t\r\n")
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		return JSON
+	}
+
+	first, _, _ := bytes.Cut(trimmed, []byte("\n"))
+	if bytes.Contains(first, []byte(",")) {
+		return CSV
+	}
+	return Text
+}
+
+// EachElf calls fn for every elf in an inventory of any Format, detected
+// from its content. Text inventories are streamed one elf at a time; CSV
+// and JSON are read whole because their elves need not be contiguous.
+func EachElf(r io.Reader, fn func(e Elf) error) error {
+	br := bufio.NewReader(r)
+
+	var elves []Elf
+	var err error
+	switch detectFormat(br) {
+	case Text:
+		elf := 0
+		return parse.EachGroup(br, func(g parse.Group) error {
+			elf++
+			e, err := parseElf(g, elf)
+			if err != nil {
+				return err
+			}
+			return fn(e)
+		})
+	case CSV:
+		elves, err = readCSV(br)
+	case JSON:
+		elves, err = readJSON(br)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, e := range elves {
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readCSV(r io.Reader) ([]Elf, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(string(data), "\n")
+	text := func(line int) string {
+		if line < 1 || line > len(lines) {
+			return ""
+		}
+		return strings.TrimSuffix(lines[line-1], "\r")
+	}
+
+	cr := csv.NewReader(bytes.NewReader(data))
+	cr.FieldsPerRecord = 2
+	cr.TrimLeadingSpace = true
+
+	elves := []Elf{}
+	position := map[int]int{}
+	for row := 0; ; row++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var ce *csv.ParseError
+			if errors.As(err, &ce) {
+				return nil, &parse.ParseError{Day: 1, Line: ce.Line, Col: ce.Column, Text: text(ce.Line), Cause: ce.Err}
+			}
+			return nil, err
+		}
+
+		if row == 0 && rec[0] == csvHeader[0] && rec[1] == csvHeader[1] {
+			continue
+		}
+
+		line, idCol := cr.FieldPos(0)
+		id, err := strconv.Atoi(rec[0])
+		if err != nil {
+			return nil, &parse.ParseError{Day: 1, Line: line, Col: idCol, Text: text(line), Cause: fmt.Errorf("elf: %w", err)}
+		}
+		if id < 1 {
+			return nil, &parse.ParseError{Day: 1, Line: line, Col: idCol, Text: text(line), Cause: fmt.Errorf("elf: id %v is not positive", id)}
+		}
+		// "01" and "1" would both be elf 1, so only the plain form is an id.
+		if rec[0] != strconv.Itoa(id) {
+			return nil, &parse.ParseError{Day: 1, Line: line, Col: idCol, Text: text(line), Cause: fmt.Errorf("elf: id %q must be written without leading zeros or sign", rec[0])}
+		}
+		calories, err := strconv.Atoi(rec[1])
+		if err != nil {
+			_, col := cr.FieldPos(1)
+			return nil, &parse.ParseError{Day: 1, Line: line, Col: col, Text: text(line), Cause: fmt.Errorf("item_calories: %w", err)}
+		}
+
+		i, ok := position[id]
+		if !ok {
+			i = len(elves)
+			position[id] = i
+			elves = append(elves, Elf{Index: id})
+		}
+		elves[i].Items = append(elves[i].Items, calories)
+	}
+	return elves, nil
+}
+
+func readJSON(r io.Reader) ([]Elf, error) {
+	var inventory [][]int
+	if err := json.NewDecoder(r).Decode(&inventory); err != nil {
+		return nil, fmt.Errorf("day1: reading JSON inventory: %w", err)
+	}
+
+	elves := make([]Elf, len(inventory))
+	for i, items := range inventory {
+		if len(items) == 0 {
+			return nil, fmt.Errorf("day1: JSON inventory: elf %v has no items", i+1)
+		}
+		elves[i] = Elf{Index: i + 1, Items: items}
+	}
+	return elves, nil
+}
+
+// WriteElves writes elves to w in format f.
+func WriteElves(w io.Writer, elves []Elf, f Format) error {
+	bw := bufio.NewWriter(w)
+	switch f {
+	case Text:
+		for i, e := range elves {
+			if i > 0 {
+				bw.WriteString("\n")
+			}
+			for _, c := range e.Items {
+				fmt.Fprintln(bw, c)
+			}
+		}
+	case CSV:
+		cw := csv.NewWriter(bw)
+		cw.Write(csvHeader)
+		for _, e := range elves {
+			for _, c := range e.Items {
+				cw.Write([]string{strconv.Itoa(e.Index), strconv.Itoa(c)})
+			}
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+	case JSON:
+		bw.WriteString("[\n")
+		for i, e := range elves {
+			items, err := json.Marshal(e.Items)
+			if err != nil {
+				return err
+			}
+			sep := ","
+			if i == len(elves)-1 {
+				sep = ""
+			}
+			fmt.Fprintf(bw, "  %s%v\n", items, sep)
+		}
+		bw.WriteString("]\n")
+	default:
+		return fmt.Errorf("day1: unknown format %q", f)
+	}
+	return bw.Flush()
+}
+
+func init() {
+	solver.RegisterCommand(1, &convertCommand{})
+}
+
+// convertCommand rewrites an inventory in another format.
+type convertCommand struct {
+	to string
+}
+
+func (*convertCommand) Name() string { return "convert" }
+
+func (*convertCommand) Synopsis() string {
+	return "rewrite the inventory as text, csv or json"
+}
+
+func (c *convertCommand) SetFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.to, "to", string(JSON), "format to write: text, csv or json")
+}
+
+func (c *convertCommand) Run(in io.Reader, out io.Writer) error {
+	f, err := ParseFormat(c.to)
+	if err != nil {
+		return err
+	}
+
+	elves, err := ReadElves(in)
+	if err != nil {
+		return err
+	}
+	return WriteElves(out, elves, f)
+}
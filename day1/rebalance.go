@@ -0,0 +1,441 @@
+package day1
+
+import (
+	"container/heap"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/asc521/aoc-2022/solver"
+)
+
+// Method picks how Rebalance partitions the items.
+type Method string
+
+const (
+	// Auto uses Exact up to ExactLimit items, giving up for Greedy when the
+	// search visits more than ExactBudget partial partitions.
+	Auto = Method("auto")
+	// Exact searches every partition with branch and bound, so the result
+	// is optimal but the time grows exponentially with the item count.
+	Exact = Method("exact")
+	// Greedy is the longest processing time heuristic: items are handed out
+	// largest first to the elf with the lightest load. Its largest load is
+	// at most 4/3 of the optimum.
+	Greedy = Method("greedy")
+)
+
+// ExactLimit is the largest number of items Auto solves exactly.
+const ExactLimit = 24
+
+// ExactBudget bounds the search Auto makes for an exact partition. Spread
+// over many elves even ExactLimit items can take far too long to prove.
+const ExactBudget = 5000000
+
+// Move is one item changing hands. From and To are the elves' Index.
+type Move struct {
+	Calories int
+	From, To int
+}
+
+// Plan is a redistribution of the items that lowers the largest load.
+type Plan struct {
+	Method Method
+	// Before and After are the largest loads without and with the moves.
+	Before, After int
+	// Loads is every elf's total after the moves, in inventory order.
+	Loads []int
+	Moves []Move
+}
+
+// item is a single item and the position in the elves slice it starts at.
+// Once partitioned only its calories matter; owners are settled by
+// matchBins.
+type item struct {
+	owner    int
+	calories int
+}
+
+// Rebalance plans how to move items between the elves so the largest
+// per-elf total is as small as possible, a multiway number partitioning
+// problem. The partition is then matched to the elves so as few items as
+// possible change hands. If the partition found is no better than the
+// current one, the plan keeps every item where it is.
+func Rebalance(elves []Elf, method Method) (Plan, error) {
+	if len(elves) == 0 {
+		return Plan{}, fmt.Errorf("day1: nothing to rebalance")
+	}
+
+	items := []item{}
+	for i, e := range elves {
+		for _, c := range e.Items {
+			items = append(items, item{owner: i, calories: c})
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].calories > items[j].calories })
+
+	var bins [][]item
+	switch method {
+	case Auto:
+		method = Greedy
+		if len(items) <= ExactLimit {
+			if b, ok := exact(items, len(elves), ExactBudget); ok {
+				bins, method = b, Exact
+			}
+		}
+		if bins == nil {
+			bins = lpt(items, len(elves))
+		}
+	case Greedy:
+		bins = lpt(items, len(elves))
+	case Exact:
+		bins, _ = exact(items, len(elves), 0)
+	default:
+		return Plan{}, fmt.Errorf("day1: unknown method %q (want %v, %v or %v)", method, Auto, Exact, Greedy)
+	}
+
+	plan := Plan{Method: method, Loads: Totals(elves)}
+	for _, t := range plan.Loads {
+		if t > plan.Before {
+			plan.Before = t
+		}
+	}
+
+	// A heuristic partition can be worse than the one the elves already
+	// have; then nothing should move.
+	plan.After = plan.Before
+	if maxLoad(bins) >= plan.Before {
+		return plan, nil
+	}
+
+	have := make([]map[int]int, len(elves))
+	for e, elf := range elves {
+		have[e] = map[int]int{}
+		for _, c := range elf.Items {
+			have[e][c]++
+		}
+	}
+
+	// For every calorie value, the elves holding more items of it than
+	// their bin needs hand the surplus to those holding fewer.
+	type change struct{ elf, n int }
+	surplus, deficit := map[int][]change{}, map[int][]change{}
+	plan.After = 0
+	for b, e := range matchBins(bins, have) {
+		plan.Loads[e] = 0
+		for _, it := range bins[b] {
+			plan.Loads[e] += it.calories
+		}
+		if plan.Loads[e] > plan.After {
+			plan.After = plan.Loads[e]
+		}
+
+		need := sizes(bins[b])
+		for c, n := range need {
+			if d := n - have[e][c]; d > 0 {
+				deficit[c] = append(deficit[c], change{elf: e, n: d})
+			}
+		}
+		for c, h := range have[e] {
+			if d := h - need[c]; d > 0 {
+				surplus[c] = append(surplus[c], change{elf: e, n: d})
+			}
+		}
+	}
+
+	for c, from := range surplus {
+		to := deficit[c]
+		sort.Slice(from, func(i, j int) bool { return from[i].elf < from[j].elf })
+		sort.Slice(to, func(i, j int) bool { return to[i].elf < to[j].elf })
+		for len(from) > 0 && len(to) > 0 {
+			plan.Moves = append(plan.Moves, Move{Calories: c, From: elves[from[0].elf].Index, To: elves[to[0].elf].Index})
+			if from[0].n--; from[0].n == 0 {
+				from = from[1:]
+			}
+			if to[0].n--; to[0].n == 0 {
+				to = to[1:]
+			}
+		}
+	}
+
+	sort.Slice(plan.Moves, func(i, j int) bool {
+		a, b := plan.Moves[i], plan.Moves[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		return a.Calories > b.Calories
+	})
+	return plan, nil
+}
+
+// binHeap orders bin numbers by load, lightest first, ties by number.
+type binHeap struct {
+	bins  []int
+	loads []int
+}
+
+func (h binHeap) Len() int { return len(h.bins) }
+func (h binHeap) Less(i, j int) bool {
+	a, b := h.bins[i], h.bins[j]
+	if h.loads[a] != h.loads[b] {
+		return h.loads[a] < h.loads[b]
+	}
+	return a < b
+}
+func (h binHeap) Swap(i, j int) { h.bins[i], h.bins[j] = h.bins[j], h.bins[i] }
+func (h *binHeap) Push(x any)   { h.bins = append(h.bins, x.(int)) }
+func (h *binHeap) Pop() any {
+	x := h.bins[len(h.bins)-1]
+	h.bins = h.bins[:len(h.bins)-1]
+	return x
+}
+
+// lpt hands the items, sorted largest first, to k bins.
+func lpt(items []item, k int) [][]item {
+	bins := make([][]item, k)
+	h := &binHeap{loads: make([]int, k)}
+	for b := 0; b < k; b++ {
+		h.bins = append(h.bins, b)
+	}
+	heap.Init(h)
+
+	for _, it := range items {
+		b := h.bins[0]
+		bins[b] = append(bins[b], it)
+		h.loads[b] += it.calories
+		heap.Fix(h, 0)
+	}
+	return bins
+}
+
+// exact finds an optimal partition of the items, sorted largest first, into
+// k bins. The greedy partition is the starting bound and the search stops
+// early once nothing better than the trivial lower bound is possible. With
+// a budget above zero the search gives up after that many steps, returning
+// false.
+func exact(items []item, k, budget int) ([][]item, bool) {
+	best := lpt(items, k)
+	bestMax := maxLoad(best)
+
+	lower, sum := 0, 0
+	for _, it := range items {
+		sum += it.calories
+	}
+	if len(items) > 0 {
+		lower = items[0].calories
+	}
+	if avg := (sum + k - 1) / k; avg > lower {
+		lower = avg
+	}
+
+	loads := make([]int, k)
+	assign := make([]int, len(items))
+	var bestAssign []int
+
+	steps := 0
+	exhausted := false
+	var search func(i, curMax int) bool
+	search = func(i, curMax int) bool {
+		steps++
+		if budget > 0 && steps > budget {
+			exhausted = true
+			return true
+		}
+		if i == len(items) {
+			bestMax = curMax
+			bestAssign = append(bestAssign[:0], assign...)
+			return bestMax <= lower
+		}
+
+		// Bins with equal loads are interchangeable, so only try one.
+		tried := map[int]bool{}
+		for b := 0; b < k; b++ {
+			if tried[loads[b]] {
+				continue
+			}
+			tried[loads[b]] = true
+
+			l := loads[b] + items[i].calories
+			if l >= bestMax {
+				continue
+			}
+
+			loads[b] = l
+			assign[i] = b
+			m := curMax
+			if l > m {
+				m = l
+			}
+			if search(i+1, m) {
+				return true
+			}
+			loads[b] -= items[i].calories
+		}
+		return false
+	}
+
+	if bestMax > lower {
+		search(0, 0)
+	}
+	if exhausted {
+		return nil, false
+	}
+	if bestAssign == nil {
+		return best, true
+	}
+
+	bins := make([][]item, k)
+	for i, b := range bestAssign {
+		bins[b] = append(bins[b], items[i])
+	}
+	return bins, true
+}
+
+func maxLoad(bins [][]item) int {
+	m := 0
+	for _, bin := range bins {
+		load := 0
+		for _, it := range bin {
+			load += it.calories
+		}
+		if load > m {
+			m = load
+		}
+	}
+	return m
+}
+
+// matchBins gives every bin to an elf so that as many items as possible
+// stay where they are. Items of equal calories are interchangeable, so an
+// elf keeps as many of a bin's items of each size as it already holds. The
+// result maps bin to elf.
+func matchBins(bins [][]item, have []map[int]int) []int {
+	kept := make([][]int, len(bins))
+	for b, bin := range bins {
+		kept[b] = make([]int, len(have))
+		need := sizes(bin)
+		for e := range have {
+			for c, n := range need {
+				if h := have[e][c]; h < n {
+					n = h
+				}
+				kept[b][e] += n
+			}
+		}
+	}
+	return maxAssignment(kept)
+}
+
+// sizes counts the items of each calorie value.
+func sizes(items []item) map[int]int {
+	n := map[int]int{}
+	for _, it := range items {
+		n[it.calories]++
+	}
+	return n
+}
+
+// maxAssignment pairs every row of the square matrix w with a distinct
+// column so the sum of the chosen weights is largest, by the Hungarian
+// method. The result maps row to column.
+func maxAssignment(w [][]int) []int {
+	n := len(w)
+	const inf = int(^uint(0) >> 2)
+
+	// Rows and columns are 1-based below; p[j] is the row given column j.
+	u := make([]int, n+1)
+	v := make([]int, n+1)
+	p := make([]int, n+1)
+	way := make([]int, n+1)
+	for i := 1; i <= n; i++ {
+		p[0] = i
+		j0 := 0
+		minv := make([]int, n+1)
+		used := make([]bool, n+1)
+		for j := range minv {
+			minv[j] = inf
+		}
+		for {
+			used[j0] = true
+			i0, delta, j1 := p[j0], inf, 0
+			for j := 1; j <= n; j++ {
+				if used[j] {
+					continue
+				}
+				if cur := -w[i0-1][j-1] - u[i0] - v[j]; cur < minv[j] {
+					minv[j], way[j] = cur, j0
+				}
+				if minv[j] < delta {
+					delta, j1 = minv[j], j
+				}
+			}
+			for j := 0; j <= n; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+			if p[j0] == 0 {
+				break
+			}
+		}
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+
+	match := make([]int, n)
+	for j := 1; j <= n; j++ {
+		match[p[j]-1] = j - 1
+	}
+	return match
+}
+
+func init() {
+	solver.RegisterCommand(1, &rebalanceCommand{})
+}
+
+// rebalanceCommand prints a Plan.
+type rebalanceCommand struct {
+	method string
+}
+
+func (*rebalanceCommand) Name() string { return "rebalance" }
+
+func (*rebalanceCommand) Synopsis() string {
+	return "suggest item moves that minimize the largest calorie load"
+}
+
+func (c *rebalanceCommand) SetFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.method, "method", string(Auto), fmt.Sprintf("auto, exact or greedy; auto tries exact up to %v items", ExactLimit))
+}
+
+func (c *rebalanceCommand) Run(in io.Reader, out io.Writer) error {
+	elves, err := ReadElves(in)
+	if err != nil {
+		return err
+	}
+
+	plan, err := Rebalance(elves, Method(c.method))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "method: %v\nlargest load: %v -> %v\nmoves: %v\n", plan.Method, plan.Before, plan.After, len(plan.Moves))
+
+	w := tabwriter.NewWriter(out, 0, 0, 1, ' ', tabwriter.AlignRight)
+	for _, m := range plan.Moves {
+		fmt.Fprintf(w, "  move\t%v\tcalories from elf\t%v\tto elf\t%v\t\n", m.Calories, m.From, m.To)
+	}
+	return w.Flush()
+}
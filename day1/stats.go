@@ -0,0 +1,270 @@
+package day1
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/asc521/aoc-2022/solver"
+)
+
+// Totals returns the calorie total of every elf in inventory order.
+func Totals(elves []Elf) []int {
+	totals := make([]int, len(elves))
+	for i, e := range elves {
+		totals[i] = e.Total()
+	}
+	return totals
+}
+
+// Mean returns the average of xs, or 0 when xs is empty.
+func Mean(xs []int) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+
+	sum := 0
+	for _, x := range xs {
+		sum += x
+	}
+	return float64(sum) / float64(len(xs))
+}
+
+// Median returns the middle value of xs.
+func Median(xs []int) float64 {
+	return Percentile(xs, 50)
+}
+
+// Percentile returns the p-th percentile of xs, for p between 0 and 100,
+// interpolating linearly between the closest ranks. xs need not be sorted
+// and is not modified.
+func Percentile(xs []int, p float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+
+	sorted := append([]int(nil), xs...)
+	sort.Ints(sorted)
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	return float64(sorted[lo]) + (rank-float64(lo))*float64(sorted[hi]-sorted[lo])
+}
+
+// ItemHistogram counts how many elves carry each number of items.
+func ItemHistogram(elves []Elf) map[int]int {
+	h := map[int]int{}
+	for _, e := range elves {
+		h[len(e.Items)]++
+	}
+	return h
+}
+
+// Outliers returns the elves whose total lies outside Tukey's fences, more
+// than 1.5 interquartile ranges below the first or above the third
+// quartile.
+func Outliers(elves []Elf) []Elf {
+	totals := Totals(elves)
+	q1, q3 := Percentile(totals, 25), Percentile(totals, 75)
+	lo, hi := q1-1.5*(q3-q1), q3+1.5*(q3-q1)
+
+	outliers := []Elf{}
+	for i, e := range elves {
+		if t := float64(totals[i]); t < lo || t > hi {
+			outliers = append(outliers, e)
+		}
+	}
+	return outliers
+}
+
+// rank returns the 1-based position of elves[i] when ordered by total.
+func rank(elves []Elf, i int) int {
+	e := Ranked{Elf: elves[i].Index, Calories: elves[i].Total()}
+	r := 1
+	for _, o := range elves {
+		if (Ranked{Elf: o.Index, Calories: o.Total()}).better(e) {
+			r++
+		}
+	}
+	return r
+}
+
+func formatFloat(x float64) string {
+	return strconv.FormatFloat(math.Round(x*100)/100, 'f', -1, 64)
+}
+
+func init() {
+	solver.RegisterCommand(1, &statsCommand{})
+}
+
+// statsCommand answers questions about the inventory beyond the two parts.
+type statsCommand struct {
+	stat      string
+	elf       int
+	over      int
+	histogram bool
+	outliers  bool
+}
+
+func (*statsCommand) Name() string { return "stats" }
+
+func (*statsCommand) Synopsis() string {
+	return "summarize the inventory or query a statistic, an elf or a threshold"
+}
+
+func (c *statsCommand) SetFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.stat, "stat", "", "print one statistic: count, total, min, max, mean, median, pNN or most-items")
+	fs.IntVar(&c.elf, "elf", 0, "show the inventory of the elf with this index: its CSV id, otherwise its 1-based position")
+	fs.IntVar(&c.over, "over", -1, "count the elves carrying more than this many calories")
+	fs.BoolVar(&c.histogram, "histogram", false, "print how many elves carry each number of items")
+	fs.BoolVar(&c.outliers, "outliers", false, "list the elves whose totals are outliers")
+}
+
+func (c *statsCommand) Run(in io.Reader, out io.Writer) error {
+	elves, err := ReadElves(in)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case c.stat != "":
+		v, err := stat(c.stat, elves)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, v)
+	case c.elf != 0:
+		i := 0
+		for i < len(elves) && elves[i].Index != c.elf {
+			i++
+		}
+		if i == len(elves) {
+			return fmt.Errorf("no elf %v in the inventory of %v", c.elf, len(elves))
+		}
+		e := elves[i]
+		fmt.Fprintf(out, "elf %v: %v items, %v calories, rank %v of %v\n", e.Index, len(e.Items), e.Total(), rank(elves, i), len(elves))
+		fmt.Fprintf(out, "items: %v\n", strings.Trim(fmt.Sprint(e.Items), "[]"))
+	case c.over >= 0:
+		n := 0
+		for _, t := range Totals(elves) {
+			if t > c.over {
+				n++
+			}
+		}
+		fmt.Fprintln(out, n)
+	case c.histogram:
+		writeHistogram(out, elves)
+	case c.outliers:
+		for _, e := range Outliers(elves) {
+			fmt.Fprintf(out, "elf %v: %v calories\n", e.Index, e.Total())
+		}
+	default:
+		writeSummary(out, elves)
+	}
+	return nil
+}
+
+// stat evaluates a named statistic over the elves.
+func stat(name string, elves []Elf) (string, error) {
+	totals := Totals(elves)
+	sorted := append([]int(nil), totals...)
+	sort.Ints(sorted)
+
+	switch name {
+	case "count":
+		return strconv.Itoa(len(elves)), nil
+	case "total":
+		sum := 0
+		for _, t := range totals {
+			sum += t
+		}
+		return strconv.Itoa(sum), nil
+	case "min":
+		return strconv.Itoa(sorted[0]), nil
+	case "max":
+		return strconv.Itoa(sorted[len(sorted)-1]), nil
+	case "mean":
+		return formatFloat(Mean(totals)), nil
+	case "median":
+		return formatFloat(Median(totals)), nil
+	case "most-items":
+		return strconv.Itoa(mostItems(elves).Index), nil
+	}
+
+	if strings.HasPrefix(name, "p") {
+		p, err := strconv.ParseFloat(name[1:], 64)
+		if err == nil && p >= 0 && p <= 100 {
+			return formatFloat(Percentile(totals, p)), nil
+		}
+	}
+	return "", fmt.Errorf("unknown statistic %q (want count, total, min, max, mean, median, pNN or most-items)", name)
+}
+
+// mostItems returns the first elf carrying the most items.
+func mostItems(elves []Elf) Elf {
+	most := elves[0]
+	for _, e := range elves[1:] {
+		if len(e.Items) > len(most.Items) {
+			most = e
+		}
+	}
+	return most
+}
+
+func writeSummary(out io.Writer, elves []Elf) {
+	totals := Totals(elves)
+	top := 0
+	for i, t := range totals {
+		if t > totals[top] {
+			top = i
+		}
+	}
+	most := mostItems(elves)
+
+	outliers := []string{}
+	for _, e := range Outliers(elves) {
+		outliers = append(outliers, strconv.Itoa(e.Index))
+	}
+	if len(outliers) == 0 {
+		outliers = append(outliers, "none")
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "elves\t%v\n", len(elves))
+	fmt.Fprintf(w, "mean\t%v\n", formatFloat(Mean(totals)))
+	fmt.Fprintf(w, "median\t%v\n", formatFloat(Median(totals)))
+	fmt.Fprintf(w, "p90\t%v\n", formatFloat(Percentile(totals, 90)))
+	fmt.Fprintf(w, "max\t%v (elf %v)\n", totals[top], elves[top].Index)
+	fmt.Fprintf(w, "most items\t%v (elf %v)\n", len(most.Items), most.Index)
+	fmt.Fprintf(w, "outliers\t%v\n", strings.Join(outliers, ", "))
+	w.Flush()
+}
+
+func writeHistogram(out io.Writer, elves []Elf) {
+	h := ItemHistogram(elves)
+
+	counts := []int{}
+	widest := 0
+	for items, n := range h {
+		counts = append(counts, items)
+		if n > widest {
+			widest = n
+		}
+	}
+	sort.Ints(counts)
+
+	const barWidth = 40
+	w := tabwriter.NewWriter(out, 0, 0, 1, ' ', tabwriter.AlignRight)
+	for _, items := range counts {
+		n := h[items]
+		bar := strings.Repeat("#", (n*barWidth+widest-1)/widest)
+		fmt.Fprintf(w, "%v items\t%v\t %v\n", items, n, bar)
+	}
+	w.Flush()
+}
@@ -0,0 +1,73 @@
+package day1
+
+import (
+	"container/heap"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Ranked is an elf's calorie total. Elf is the elf's Index.
+type Ranked struct {
+	Elf      int
+	Calories int
+}
+
+// better reports whether a ranks above b: more calories, with ties going to
+// the elf that appears first.
+func (a Ranked) better(b Ranked) bool {
+	if a.Calories != b.Calories {
+		return a.Calories > b.Calories
+	}
+	return a.Elf < b.Elf
+}
+
+// topHeap is a min-heap with the lowest ranked elf at the root.
+type topHeap []Ranked
+
+func (h topHeap) Len() int           { return len(h) }
+func (h topHeap) Less(i, j int) bool { return h[j].better(h[i]) }
+func (h topHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *topHeap) Push(x any)        { *h = append(*h, x.(Ranked)) }
+func (h *topHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// TopK returns the k elves carrying the most calories, most first. The
+// inventory may be in any Format; text inventories are streamed one elf at
+// a time and only k totals are kept, so memory does not grow with the size
+// of the input. Fewer than k elves are returned when the inventory is
+// shorter.
+func TopK(r io.Reader, k int) ([]Ranked, error) {
+	if k < 1 {
+		return nil, fmt.Errorf("day1: k must be at least 1, got %v", k)
+	}
+
+	h := make(topHeap, 0, k)
+	elves := 0
+	err := EachElf(r, func(inventory Elf) error {
+		elves++
+		e := Ranked{Elf: inventory.Index, Calories: inventory.Total()}
+		if h.Len() < k {
+			heap.Push(&h, e)
+		} else if e.better(h[0]) {
+			h[0] = e
+			heap.Fix(&h, 0)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if elves == 0 {
+		return nil, fmt.Errorf("day1: inventory has no elves")
+	}
+
+	top := []Ranked(h)
+	sort.Slice(top, func(i, j int) bool { return top[i].better(top[j]) })
+	return top, nil
+}
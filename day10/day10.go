@@ -1,12 +1,15 @@
-package main
+package day10
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
-	"os"
 	"strconv"
 	"strings"
+
+	"github.com/asc521/aoc-2022/parse"
+	"github.com/asc521/aoc-2022/solver"
 )
 
 type ClockCircuit struct {
@@ -44,10 +47,11 @@ type CRT struct {
 	width  int
 	height int
 	pixels int
+	screen strings.Builder
 }
 
 func NewCRT(width, height int) *CRT {
-	return &CRT{width, height, width * height}
+	return &CRT{width: width, height: height, pixels: width * height}
 }
 
 func (crt *CRT) Draw(cycle int, spriteLocation int) {
@@ -55,16 +59,20 @@ func (crt *CRT) Draw(cycle int, spriteLocation int) {
 	rightPixel := spriteLocation + 1
 	pixelLoc := (cycle - 1) % crt.width
 	if leftPixel <= pixelLoc && pixelLoc <= rightPixel {
-		fmt.Print("#")
+		crt.screen.WriteString("#")
 	} else {
-		fmt.Print(".")
+		crt.screen.WriteString(".")
 	}
 
-	if cycle%40 == 0 {
-		fmt.Print("\n")
+	if cycle%crt.width == 0 {
+		crt.screen.WriteString("\n")
 	}
 }
 
+func (crt *CRT) String() string {
+	return crt.screen.String()
+}
+
 type VideoSystem struct {
 	Circuit           *ClockCircuit
 	CPU               *CPU
@@ -81,17 +89,23 @@ func NewVideoSystem(screenWidth, screenHeight int) *VideoSystem {
 	}
 }
 
-func (v *VideoSystem) RunProgram(r io.Reader) {
+func (v *VideoSystem) RunProgram(r io.Reader) error {
+	lineNum := 0
 	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		line := scanner.Text()
+		lineNum++
 		args := strings.Split(line, " ")
 
 		switch args[0] {
 		case "addx":
+			if len(args) != 2 {
+				return &parse.ParseError{Day: 10, Line: lineNum, Col: len(line) + 1, Text: line, Cause: errors.New("addx takes exactly one argument")}
+			}
+
 			amount, err := strconv.Atoi(args[1])
 			if err != nil {
-				panic(err)
+				return &parse.ParseError{Day: 10, Line: lineNum, Col: 6, Text: line, Cause: err}
 			}
 
 			v.CRT.Draw(v.Circuit.Cycle, v.CPU.X)
@@ -103,34 +117,52 @@ func (v *VideoSystem) RunProgram(r io.Reader) {
 			v.CPU.AddX(amount)
 			v.Circuit.CompleteCycle()
 		case "noop":
+			if len(args) != 1 {
+				return &parse.ParseError{Day: 10, Line: lineNum, Col: 6, Text: line, Cause: errors.New("noop takes no arguments")}
+			}
+
 			v.CPU.Noop()
 
 			v.CRT.Draw(v.Circuit.Cycle, v.CPU.X)
 			v.LogSignalStrength()
 			v.Circuit.CompleteCycle()
 		default:
-			panic("I should never get here")
+			return &parse.ParseError{Day: 10, Line: lineNum, Col: 1, Text: line, Cause: fmt.Errorf("unknown instruction %q", args[0])}
 		}
 	}
+
+	return scanner.Err()
 }
 
 func (v *VideoSystem) LogSignalStrength() {
 	v.SignalStrengthLog[v.Circuit.Cycle] = v.Circuit.Cycle * v.CPU.X
 }
 
-func main() {
-	file, err := os.Open("/Users/alex.curto/code/aoc-2022/day10/input.txt")
-	if err != nil {
-		panic(err)
+func init() {
+	solver.Register(10, Solver{})
+}
+
+// Solver answers day 10 from the CPU's program.
+type Solver struct{}
+
+func (Solver) PartOne(r io.Reader) (string, error) {
+	vs := NewVideoSystem(40, 6)
+	if err := vs.RunProgram(r); err != nil {
+		return "", err
 	}
 
+	cycles := []int{20, 60, 100, 140, 180, 220}
+	sum := 0
+	for _, c := range cycles {
+		sum += vs.SignalStrengthLog[c]
+	}
+	return strconv.Itoa(sum), nil
+}
+
+func (Solver) PartTwo(r io.Reader) (string, error) {
 	vs := NewVideoSystem(40, 6)
-	vs.RunProgram(file)
-	// fmt.Println(vs.SignalStrengthLog)
-	// cycles := []int{20, 60, 100, 140, 180, 220}
-	// sum := 0
-	// for _, c := range cycles {
-	// 	sum += vs.SignalStrengthLog[c]
-	// }
-	// fmt.Println(sum)
+	if err := vs.RunProgram(r); err != nil {
+		return "", err
+	}
+	return strings.TrimRight(vs.CRT.String(), "\n"), nil
 }
@@ -0,0 +1,211 @@
+package day11
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/asc521/aoc-2022/logger"
+	"github.com/asc521/aoc-2022/parse"
+	"github.com/asc521/aoc-2022/solver"
+)
+
+type Operation struct {
+	op     string
+	amount int
+}
+
+type Monkey struct {
+	id             string
+	holding        []int
+	numInpsections int
+	test           int
+	operation      Operation
+	true           int
+	false          int
+}
+
+func NewMonkey(id string) *Monkey {
+	return &Monkey{id: id}
+}
+
+func init() {
+	solver.Register(11, Solver{})
+}
+
+// Solver answers day 11 from the monkeys' notes.
+type Solver struct{}
+
+func (Solver) PartOne(r io.Reader) (string, error) {
+	monkeys, err := parseMonkeys(r)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(monkeyBusiness(monkeys, 20, true)), nil
+}
+
+func (Solver) PartTwo(r io.Reader) (string, error) {
+	monkeys, err := parseMonkeys(r)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(monkeyBusiness(monkeys, 10000, false)), nil
+}
+
+// The notes for each monkey are six lines in a fixed order.
+var monkeyNotes = []*parse.Record{
+	parse.NewRecord(regexp.MustCompile(`^Monkey (?P<id>[0-9]+):$`)),
+	parse.NewRecord(regexp.MustCompile(`^\s*Starting items: (?P<items>[0-9]+(, [0-9]+)*)?$`)),
+	parse.NewRecord(regexp.MustCompile(`^\s*Operation: new = old (?P<op>[*+]) (?P<amount>old|[0-9]+)$`)),
+	parse.NewRecord(regexp.MustCompile(`^\s*Test: divisible by (?P<test>[0-9]+)$`)),
+	parse.NewRecord(regexp.MustCompile(`^\s*If true: throw to monkey (?P<true>[0-9]+)$`)),
+	parse.NewRecord(regexp.MustCompile(`^\s*If false: throw to monkey (?P<false>[0-9]+)$`)),
+}
+
+// monkeyRecord collects the fields decoded from one monkey's notes.
+type monkeyRecord struct {
+	ID     string `re:"id"`
+	Items  []int  `re:"items"`
+	Op     string `re:"op"`
+	Amount string `re:"amount"`
+	Test   int    `re:"test"`
+	True   int    `re:"true"`
+	False  int    `re:"false"`
+}
+
+func parseMonkeys(r io.Reader) ([]*Monkey, error) {
+
+	monkeys := []*Monkey{}
+	err := parse.EachGroup(r, func(g parse.Group) error {
+		if len(g.Lines) != len(monkeyNotes) {
+			return &parse.ParseError{Day: 11, Line: g.Line, Col: 1, Text: g.Lines[0], Cause: fmt.Errorf("monkey has %v lines of notes, want %v", len(g.Lines), len(monkeyNotes))}
+		}
+
+		var rec monkeyRecord
+		for i, line := range g.Lines {
+			if err := monkeyNotes[i].Decode(line, &rec); err != nil {
+				var pe *parse.ParseError
+				if errors.As(err, &pe) {
+					pe.Day, pe.Line = 11, g.Line+i
+				}
+				return err
+			}
+		}
+
+		if rec.Test <= 0 {
+			return &parse.ParseError{Day: 11, Line: g.Line + 3, Text: g.Lines[3], Cause: errors.New("divisor must be positive")}
+		}
+
+		monkey := NewMonkey(rec.ID)
+		monkey.holding = rec.Items
+		monkey.test = rec.Test
+		monkey.true = rec.True
+		monkey.false = rec.False
+
+		if rec.Amount == "old" {
+			if rec.Op != "*" {
+				return &parse.ParseError{Day: 11, Line: g.Line + 2, Text: g.Lines[2], Cause: errors.New("only old * old is supported")}
+			}
+			monkey.operation = Operation{op: "^", amount: 2}
+		} else {
+			amt, err := strconv.Atoi(rec.Amount)
+			if err != nil {
+				return &parse.ParseError{Day: 11, Line: g.Line + 2, Text: g.Lines[2], Cause: err}
+			}
+			monkey.operation = Operation{op: rec.Op, amount: amt}
+		}
+
+		monkeys = append(monkeys, monkey)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range monkeys {
+		for _, target := range []int{m.true, m.false} {
+			if target < 0 || target >= len(monkeys) {
+				return nil, &parse.ParseError{Day: 11, Cause: fmt.Errorf("monkey %v throws to monkey %v, which does not exist", m.id, target)}
+			}
+		}
+	}
+
+	if len(monkeys) < 2 {
+		return nil, &parse.ParseError{Day: 11, Cause: fmt.Errorf("need at least 2 monkeys, got %v", len(monkeys))}
+	}
+
+	return monkeys, nil
+}
+
+// monkeyBusiness plays the given number of rounds and multiplies the
+// inspection counts of the two most active monkeys. With relief the worry
+// level is divided by three after each inspection; without it the level is
+// kept in check modulo the product of every monkey's divisor.
+func monkeyBusiness(monkeys []*Monkey, rounds int, relief bool) int {
+
+	gcd := 1
+	for _, monkey := range monkeys {
+		gcd *= monkey.test
+	}
+
+	for i := 0; i < rounds; i++ {
+		for _, m := range monkeys {
+			for _, item := range m.holding {
+				var wl int
+				switch m.operation.op {
+				case "*":
+					wl = item * m.operation.amount
+				case "+":
+					wl = item + m.operation.amount
+				case "^":
+					if m.operation.amount == 2 {
+						wl = item * item
+					} else {
+						wl = int(math.Pow(float64(item), float64(m.operation.amount)))
+					}
+
+				default:
+					msg := fmt.Sprintf("Missing support for operation %v", m.operation.op)
+					panic(msg)
+				}
+
+				if relief {
+					wl = wl / 3
+				} else {
+					wl %= gcd
+				}
+
+				var throwTo int
+				if wl%m.test == 0 {
+					throwTo = m.true
+				} else {
+					throwTo = m.false
+				}
+
+				monkeys[throwTo].holding = append(monkeys[throwTo].holding, wl)
+				m.numInpsections++
+
+			}
+			m.holding = []int{}
+		}
+	}
+
+	for _, m := range monkeys {
+		logger.Debugf("Monkey %v : %v", m.id, m.holding)
+	}
+
+	var inspections []int
+	for _, m := range monkeys {
+		inspections = append(inspections, m.numInpsections)
+		logger.Infof("Monkey %v %v inspections", m.id, m.numInpsections)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(inspections)))
+	logger.Debugf("%v", inspections)
+	return inspections[0] * inspections[1]
+}
@@ -0,0 +1,197 @@
+package day12
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/asc521/aoc-2022/grid"
+	"github.com/asc521/aoc-2022/parse"
+	"github.com/asc521/aoc-2022/solver"
+)
+
+type Square struct {
+	elevation int
+	pos       grid.Point
+}
+
+type Grid struct {
+	squares *grid.Grid[*Square]
+}
+
+func (g Grid) FindNeighbors(s *Square) []*Square {
+
+	n := []*Square{}
+	for _, p := range g.squares.Neighbors4(s.pos) {
+		sq, _ := g.squares.At(p)
+		n = append(n, sq)
+	}
+
+	return n
+
+}
+
+func elevationMap() map[string]int {
+	elevations := map[string]int{}
+	e := 1
+	for ch := 'a'; ch <= 'z'; ch++ {
+		elevations[string(ch)] = e
+		e += 1
+	}
+	return elevations
+}
+
+func (g Grid) computeShortestDistance(start, end *Square) int {
+
+	visited := map[*Square]bool{}
+	unvisited := []*Square{start}
+
+	distances := grid.New[int](g.squares.Rows(), g.squares.Cols())
+	distances.Each(func(p grid.Point, _ int) {
+		distances.Set(p, -1)
+	})
+
+	distances.Set(start.pos, 0)
+
+	for {
+
+		cs := unvisited[0]
+		visited[cs] = true
+		csDistance, _ := distances.At(cs.pos)
+
+		neighbors := g.FindNeighbors(cs)
+		for _, n := range neighbors {
+
+			if n.elevation > cs.elevation+1 {
+				continue
+			}
+
+			if d, _ := distances.At(n.pos); visited[n] || d != -1 {
+				continue
+			}
+
+			unvisited = append(unvisited, n)
+
+			distances.Set(n.pos, csDistance+1)
+
+		}
+
+		if cs == end {
+			break
+		}
+
+		unvisited = unvisited[1:]
+
+		if len(unvisited) == 0 {
+			break
+		}
+
+	}
+
+	d, _ := distances.At(end.pos)
+	return d
+
+}
+
+// heightmap is a parsed puzzle input: the grid, the marked start and end
+// squares, and every square at the lowest elevation.
+type heightmap struct {
+	grid   Grid
+	start  *Square
+	end    *Square
+	starts []*Square
+}
+
+func parseHeightmap(r io.Reader) (*heightmap, error) {
+
+	elevations := elevationMap()
+
+	var start, end *Square
+	var starts []*Square
+	squares, err := grid.Parse(r, func(p grid.Point, ch rune) (*Square, error) {
+		s := string(ch)
+		switch s {
+		case "S":
+			if start != nil {
+				return nil, errors.New("second start square")
+			}
+			start = &Square{elevation: elevations["a"], pos: p}
+			starts = append(starts, start)
+			return start, nil
+		case "a":
+			sq := &Square{elevation: elevations["a"], pos: p}
+			starts = append(starts, sq)
+			return sq, nil
+		case "E":
+			if end != nil {
+				return nil, errors.New("second end square")
+			}
+			end = &Square{elevation: elevations["z"], pos: p}
+			return end, nil
+		default:
+			elevation, ok := elevations[s]
+			if !ok {
+				return nil, fmt.Errorf("invalid elevation %q, want a-z, S or E", s)
+			}
+			return &Square{elevation: elevation, pos: p}, nil
+		}
+	})
+	if err != nil {
+		var pe *parse.ParseError
+		if errors.As(err, &pe) {
+			pe.Day = 12
+		}
+		return nil, err
+	}
+
+	if start == nil || end == nil {
+		return nil, &parse.ParseError{Day: 12, Cause: errors.New("heightmap is missing a start or end square")}
+	}
+
+	return &heightmap{grid: Grid{squares: squares}, start: start, end: end, starts: starts}, nil
+}
+
+func init() {
+	solver.Register(12, Solver{})
+}
+
+// Solver answers day 12 from a heightmap of the surrounding area.
+type Solver struct{}
+
+func (Solver) PartOne(r io.Reader) (string, error) {
+	hm, err := parseHeightmap(r)
+	if err != nil {
+		return "", err
+	}
+
+	d := hm.grid.computeShortestDistance(hm.start, hm.end)
+	if d == -1 {
+		return "", fmt.Errorf("day12: no path from start to end")
+	}
+	return strconv.Itoa(d), nil
+}
+
+func (Solver) PartTwo(r io.Reader) (string, error) {
+	hm, err := parseHeightmap(r)
+	if err != nil {
+		return "", err
+	}
+
+	distances := []int{}
+	for _, start := range hm.starts {
+		d := hm.grid.computeShortestDistance(start, hm.end)
+		if d == -1 {
+			continue
+		}
+		distances = append(distances, d)
+	}
+
+	if len(distances) == 0 {
+		return "", fmt.Errorf("day12: no path from any lowest square to end")
+	}
+
+	sort.Ints(distances)
+	return strconv.Itoa(distances[0]), nil
+}
@@ -0,0 +1,157 @@
+package day13
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/asc521/aoc-2022/parse"
+	"github.com/asc521/aoc-2022/solver"
+)
+
+func cmp(left, right any) int {
+
+	ls, lOK := left.([]any)
+	rs, rOK := right.([]any)
+
+	switch {
+	// Both INTS
+	case !lOK && !rOK:
+		return int(left.(float64) - right.(float64))
+	// Left is an int
+	case !lOK:
+		ls = []any{left}
+
+	// Right is an int
+	case !rOK:
+		rs = []any{right}
+	}
+
+	for i := 0; i < len(ls) && i < len(rs); i++ {
+		if c := cmp(ls[i], rs[i]); c != 0 {
+			return c
+		}
+	}
+
+	return len(ls) - len(rs)
+}
+
+func init() {
+	solver.Register(13, Solver{})
+}
+
+// Solver answers day 13 from a list of packet pairs.
+type Solver struct{}
+
+func readPairs(r io.Reader) ([][2]any, error) {
+
+	pairs := [][2]any{}
+	var pending []any
+	lineNum := 0
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+		lineNum++
+		if strings.TrimSpace(line) == "" {
+			if len(pending) == 1 {
+				return nil, &parse.ParseError{Day: 13, Line: lineNum - 1, Cause: errors.New("packet has no pair")}
+			}
+			continue
+		}
+
+		var pkt any
+		if err := json.Unmarshal([]byte(line), &pkt); err != nil {
+			col := 1
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				col = int(syntaxErr.Offset)
+			}
+			return nil, &parse.ParseError{Day: 13, Line: lineNum, Col: col, Text: line, Cause: err}
+		}
+
+		if err := checkPacket(pkt, true); err != nil {
+			return nil, &parse.ParseError{Day: 13, Line: lineNum, Col: 1, Text: line, Cause: err}
+		}
+
+		pending = append(pending, pkt)
+		if len(pending) == 2 {
+			pairs = append(pairs, [2]any{pending[0], pending[1]})
+			pending = pending[:0]
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(pending) == 1 {
+		return nil, &parse.ParseError{Day: 13, Line: lineNum, Cause: errors.New("packet has no pair")}
+	}
+
+	return pairs, nil
+}
+
+// checkPacket reports anything cmp cannot compare. Packets are lists whose
+// elements are integers or lists.
+func checkPacket(v any, top bool) error {
+	switch v := v.(type) {
+	case float64:
+		if top {
+			return errors.New("packet must be a list")
+		}
+		return nil
+	case []any:
+		for _, e := range v {
+			if err := checkPacket(e, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("packets may only hold integers and lists, got %v", v)
+	}
+}
+
+func (Solver) PartOne(r io.Reader) (string, error) {
+	pairs, err := readPairs(r)
+	if err != nil {
+		return "", err
+	}
+
+	part1 := 0
+	for i, p := range pairs {
+		if cmp(p[0], p[1]) <= 0 {
+			part1 += i + 1
+		}
+	}
+
+	return strconv.Itoa(part1), nil
+}
+
+func (Solver) PartTwo(r io.Reader) (string, error) {
+	pairs, err := readPairs(r)
+	if err != nil {
+		return "", err
+	}
+
+	pkts := []any{}
+	for _, p := range pairs {
+		pkts = append(pkts, p[0], p[1])
+	}
+
+	pkts = append(pkts, []any{[]any{2.}}, []any{[]any{6.}})
+	sort.Slice(pkts, func(i, j int) bool { return cmp(pkts[i], pkts[j]) < 0 })
+
+	part2 := 1
+	for i, p := range pkts {
+		if fmt.Sprint(p) == "[[2]]" || fmt.Sprint(p) == "[[6]]" {
+			part2 *= i + 1
+		}
+	}
+
+	return strconv.Itoa(part2), nil
+}
@@ -0,0 +1,14 @@
+package day13
+
+import (
+	"testing"
+
+	"github.com/asc521/aoc-2022/solver/solvertest"
+)
+
+func TestSolver(t *testing.T) {
+	solvertest.Run(t, Solver{}, []solvertest.Case{
+		{Name: "example part 1", File: "example.txt", Part: 1, Want: "13"},
+		{Name: "example part 2", File: "example.txt", Part: 2, Want: "140"},
+	})
+}
@@ -0,0 +1,198 @@
+package day2
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/asc521/aoc-2022/logger"
+	"github.com/asc521/aoc-2022/parse"
+	"github.com/asc521/aoc-2022/solver"
+)
+
+/*
+--- Day 2: Rock Paper Scissors ---
+
+The Elves begin to set up camp on the beach. To decide whose tent gets to be closest to the snack storage, a giant Rock Paper Scissors tournament is already in progress.
+
+Rock Paper Scissors is a game between two players. Each game contains many rounds; in each round, the players each simultaneously choose one of Rock, Paper, or Scissors using a hand shape. Then, a winner for that round is selected: Rock defeats Scissors, Scissors defeats Paper, and Paper defeats Rock. If both players choose the same shape, the round instead ends in a draw.
+
+Appreciative of your help yesterday, one Elf gives you an encrypted strategy guide (your puzzle input) that they say will be sure to help you win. "The first column is what your opponent is going to play: A for Rock, B for Paper, and C for Scissors. The second column--" Suddenly, the Elf is called away to help with someone's tent.
+
+The second column, you reason, must be what you should play in response: X for Rock, Y for Paper, and Z for Scissors. Winning every time would be suspicious, so the responses must have been carefully chosen.
+
+The winner of the whole tournament is the player with the highest score. Your total score is the sum of your scores for each round. The score for a single round is the score for the shape you selected (1 for Rock, 2 for Paper, and 3 for Scissors) plus the score for the outcome of the round (0 if you lost, 3 if the round was a draw, and 6 if you won).
+
+Since you can't be sure if the Elf is trying to help you or trick you, you should calculate the score you would get if you were to follow the strategy guide.
+
+For example, suppose you were given the following strategy guide:
+
+A Y
+B X
+C Z
+
+This strategy guide predicts and recommends the following:
+
+    In the first round, your opponent will choose Rock (A), and you should choose Paper (Y). This ends in a win for you with a score of 8 (2 because you chose Paper + 6 because you won).
+    In the second round, your opponent will choose Paper (B), and you should choose Rock (X). This ends in a loss for you with a score of 1 (1 + 0).
+    The third round is a draw with both players choosing Scissors, giving you a score of 3 + 3 = 6.
+
+In this example, if you were to follow the strategy guide, you would get a total score of 15 (8 + 1 + 6).
+
+What would your total score be if everything goes exactly according to your strategy guide?
+
+https://adventofcode.com/2022/day/2
+*/
+
+// Play is a shape that can be thrown. value is the score for throwing it
+// and beats the names of the shapes it defeats.
+type Play struct {
+	name  string
+	value int
+	beats []string
+}
+
+func (p Play) String() string {
+	return p.name
+}
+
+// Beats reports whether p defeats other.
+func (p Play) Beats(other Play) bool {
+	for _, b := range p.beats {
+		if b == other.name {
+			return true
+		}
+	}
+	return false
+}
+
+type Result = string
+
+// Scores and results of the classic game. Rule sets may score outcomes
+// differently.
+const (
+	LossValue = 0
+	DrawValue = 3
+	WinValue  = 6
+	Win       = Result("win")
+	Draw      = Result("draw")
+	Loss      = Result("loss")
+)
+
+func (g *Game) shootPartOne(opponent, mine Play) int {
+	return g.outcomes[g.Outcome(opponent, mine)] + mine.value
+}
+
+// shootPartTwo returns the shape to play for result against opponent and
+// the score it gives.
+func (g *Game) shootPartTwo(opponent Play, result Result) (Play, int, error) {
+	mine, err := g.Respond(opponent, result)
+	if err != nil {
+		return Play{}, 0, err
+	}
+	return mine, g.outcomes[result] + mine.value, nil
+}
+
+func init() {
+	solver.Register(2, Solver{})
+}
+
+// Solver answers day 2 from an encrypted strategy guide. Game defaults to
+// Classic.
+type Solver struct {
+	Game *Game
+}
+
+func (s Solver) PartOne(r io.Reader) (string, error) {
+	return s.game().totalScore(r, 1)
+}
+
+func (s Solver) PartTwo(r io.Reader) (string, error) {
+	return s.game().totalScore(r, 2)
+}
+
+func (s Solver) game() *Game {
+	if s.Game == nil {
+		return Classic
+	}
+	return s.Game
+}
+
+func (g *Game) totalScore(r io.Reader, part int) (string, error) {
+	var totalScore int
+	err := g.eachRound(r, part, func(rd Round) error {
+		logger.Infof("Score For Round %v: %v", rd.Number, rd.Score)
+		totalScore += rd.Score
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(totalScore), nil
+}
+
+// Round is one decrypted line of the guide. Number counts from 1.
+type Round struct {
+	Number   int
+	Opponent Play
+	Mine     Play
+	Result   Result
+	Score    int
+}
+
+// eachRound decrypts the guide line by line, reading the second column as
+// a shape for part 1 and as a result for part 2, and calls fn with every
+// round.
+func (g *Game) eachRound(r io.Reader, part int, fn func(rd Round) error) error {
+	if part != 1 && part != 2 {
+		return fmt.Errorf("day2: part must be 1 or 2, got %v", part)
+	}
+
+	round := 1
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		plays := strings.Fields(line)
+		if len(plays) != 2 {
+			return &parse.ParseError{Day: 2, Line: round, Col: 1, Text: line, Cause: fmt.Errorf("want 2 columns, got %v", len(plays))}
+		}
+
+		oppPlay, ok := g.opponentKey[plays[0]]
+		if !ok {
+			return &parse.ParseError{Day: 2, Line: round, Col: strings.Index(line, plays[0]) + 1, Text: line, Cause: fmt.Errorf("unknown opponent move %q", plays[0])}
+		}
+
+		rd := Round{Number: round, Opponent: oppPlay}
+		mineCol := strings.LastIndex(line, plays[1]) + 1
+		if part == 1 {
+			myPlay, ok := g.responseKey[plays[1]]
+			if !ok {
+				return &parse.ParseError{Day: 2, Line: round, Col: mineCol, Text: line, Cause: fmt.Errorf("unknown response %q", plays[1])}
+			}
+			rd.Mine = myPlay
+			rd.Result = g.Outcome(oppPlay, myPlay)
+			rd.Score = g.shootPartOne(oppPlay, myPlay)
+		} else {
+			r, ok := g.outcomeKey[plays[1]]
+			if !ok {
+				return &parse.ParseError{Day: 2, Line: round, Col: mineCol, Text: line, Cause: fmt.Errorf("unknown outcome %q", plays[1])}
+			}
+			mine, score, err := g.shootPartTwo(oppPlay, r)
+			if err != nil {
+				return &parse.ParseError{Day: 2, Line: round, Col: mineCol, Text: line, Cause: err}
+			}
+			rd.Mine = mine
+			rd.Result = r
+			rd.Score = score
+		}
+
+		if err := fn(rd); err != nil {
+			return err
+		}
+		round += 1
+	}
+	return scanner.Err()
+}
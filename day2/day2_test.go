@@ -0,0 +1,166 @@
+package day2
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/asc521/aoc-2022/solver/solvertest"
+)
+
+func TestSolver(t *testing.T) {
+	solvertest.Run(t, Solver{}, []solvertest.Case{
+		{Name: "example part 1", File: "strategy_guide.txt", Part: 1, Want: "15"},
+		{Name: "example part 2", File: "strategy_guide.txt", Part: 2, Want: "12"},
+	})
+}
+
+func TestRPSLS(t *testing.T) {
+	g, err := LoadGame("rpsls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	solvertest.Run(t, Solver{Game: g}, []solvertest.Case{
+		{Name: "example part 1", File: "strategy_guide.txt", Part: 1, Want: "15"},
+		// Losing to paper and beating scissors are both best done with Spock.
+		{Name: "example part 2", File: "strategy_guide.txt", Part: 2, Want: "20"},
+		{Name: "lizard and spock", Input: "D V\nE W\n", Part: 1, Want: "15"},
+	})
+}
+
+func TestRulesRejected(t *testing.T) {
+	const shapes = `"shapes": [{"name": "Rock", "score": 1}, {"name": "Paper", "score": 2}, {"name": "Scissors", "score": 3}]`
+	const outcomes = `"outcomes": {"win": 6, "draw": 3, "loss": 0}`
+
+	tests := []struct {
+		name  string
+		rules string
+	}{
+		{"even number of shapes", `{"shapes": [{"name": "Rock"}, {"name": "Paper"}], ` + outcomes + `}`},
+		{"dominant shape", `{` + shapes + `, "beats": {"Rock": ["Paper", "Scissors"], "Paper": ["Scissors"]}, ` + outcomes + `}`},
+		{"mutual beats", `{` + shapes + `, "beats": {"Rock": ["Paper"], "Paper": ["Rock"], "Scissors": ["Paper"]}, ` + outcomes + `}`},
+		{"duplicate beats", `{"shapes": [{"name": "A"}, {"name": "B"}, {"name": "C"}, {"name": "D"}, {"name": "E"}], "beats": {"A": ["B", "B"], "B": ["C", "D"], "C": ["D", "E"], "D": ["E", "A"], "E": ["A", "B"]}, ` + outcomes + `}`},
+		{"unknown shape", `{` + shapes + `, "beats": {"Rock": ["Spock"], "Paper": ["Rock"], "Scissors": ["Paper"]}, ` + outcomes + `}`},
+		{"missing outcome", `{` + shapes + `, "beats": {"Rock": ["Scissors"], "Paper": ["Rock"], "Scissors": ["Paper"]}, "outcomes": {"win": 6}}`},
+	}
+	for _, tt := range tests {
+		if _, err := ReadRules(strings.NewReader(tt.rules)); err == nil {
+			t.Errorf("%v: ReadRules succeeded", tt.name)
+		}
+	}
+}
+
+func TestInferMappings(t *testing.T) {
+	f, err := os.Open("strategy_guide.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	mappings, err := InferMappings(f, Classic)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(mappings) != 12 {
+		t.Errorf("got %v mappings, want 6 shape and 6 outcome keys", len(mappings))
+	}
+	if best := mappings[0]; best.Score != 24 || best.String() != "X=Scissors Y=Paper Z=Rock" {
+		t.Errorf("best mapping = %v scoring %v, want X=Scissors Y=Paper Z=Rock scoring 24", best, best.Score)
+	}
+
+	// The puzzle's own keys must be among those giving its answers.
+	want := map[string]int{
+		AsShape + " X=Rock Y=Paper Z=Scissors": 15,
+		AsOutcome + " X=loss Y=draw Z=win":     12,
+	}
+	for _, m := range mappings {
+		if score, ok := want[m.Interpretation+" "+m.String()]; ok {
+			if m.Score != score {
+				t.Errorf("%v %v scores %v, want %v", m.Interpretation, m, m.Score, score)
+			}
+			delete(want, m.Interpretation+" "+m.String())
+		}
+	}
+	for k := range want {
+		t.Errorf("mapping %v not tried", k)
+	}
+}
+
+func TestTournament(t *testing.T) {
+	rock := Classic.byName["Rock"]
+	tour := Tournament{
+		Game:       Classic,
+		Strategies: []Strategy{&FollowGuide{Moves: []Play{rock}}, Frequency{}, BeatLast{}, Random{}},
+		Rounds:     100,
+		Seeds:      []int64{1, 2, 3},
+	}
+
+	standings := tour.Run()
+	if again := tour.Run(); !reflect.DeepEqual(standings, again) {
+		t.Errorf("same seeds gave different standings:\n%v\n%v", standings, again)
+	}
+
+	for _, s := range standings {
+		if s.Matches != 9 || s.Rounds() != 900 || len(s.Scores) != 9 {
+			t.Errorf("%v played %v matches of %v rounds in total, want 9 of 900", s.Strategy, s.Matches, s.Rounds())
+		}
+	}
+
+	// Always throwing rock loses every round but the first to both
+	// strategies that read the opponent.
+	if guide := standings[0]; guide.Losses < 2*3*99 {
+		t.Errorf("guide lost %v rounds, want at least %v", guide.Losses, 2*3*99)
+	}
+
+	// Scores only come from the classic constants and a shape value.
+	for _, score := range standings[0].Scores {
+		if score < 100*(LossValue+1) || score > 100*(WinValue+1) {
+			t.Errorf("rock-only match scored %v", score)
+		}
+	}
+}
+
+func TestGuideEntrants(t *testing.T) {
+	strategies, err := parseStrategies("guide,random,guide", strings.NewReader("A Y\nB X\nC Z\n"), Classic)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, i := range []int{0, 2} {
+		g, ok := strategies[i].(*FollowGuide)
+		if !ok || len(g.Moves) != 3 {
+			t.Errorf("entrant %v = %#v, want the guide's 3 moves", i, strategies[i])
+		}
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	guide := "A Y\nB X\nC Z\nA Y\nA Y\nB Z\nA X\nA X\n"
+	rep, err := Classic.Analyze(strings.NewReader(guide), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rep.Total != 48 {
+		t.Errorf("Total = %v, want 48", rep.Total)
+	}
+	if want := map[Result]int{Win: 4, Draw: 3, Loss: 1}; !reflect.DeepEqual(rep.Outcomes, want) {
+		t.Errorf("Outcomes = %v, want %v", rep.Outcomes, want)
+	}
+	if want := map[string]int{"Rock": 5, "Paper": 2, "Scissors": 1}; !reflect.DeepEqual(rep.OpponentMoves, want) {
+		t.Errorf("OpponentMoves = %v, want %v", rep.OpponentMoves, want)
+	}
+	if want := (streak{Start: 4, Len: 3}); rep.LongestWin != want {
+		t.Errorf("LongestWin = %v, want %v", rep.LongestWin, want)
+	}
+	if want := (streak{Start: 2, Len: 1}); rep.LongestLoss != want {
+		t.Errorf("LongestLoss = %v, want %v", rep.LongestLoss, want)
+	}
+
+	if got, want := Sparkline(rep.Cumulative, 4), "_:#@"; got != want {
+		t.Errorf("Sparkline = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,208 @@
+package day2
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/asc521/aoc-2022/parse"
+	"github.com/asc521/aoc-2022/solver"
+)
+
+// Interpretations of the second column of the guide.
+const (
+	AsShape   = "shape"
+	AsOutcome = "outcome"
+)
+
+// Mapping is one way of decrypting the second column: every symbol stands
+// for a shape or for a result, and Score is the guide's total under it.
+type Mapping struct {
+	Interpretation string
+	Key            map[string]string
+	Score          int
+}
+
+func (m Mapping) String() string {
+	symbols := make([]string, 0, len(m.Key))
+	for s := range m.Key {
+		symbols = append(symbols, s)
+	}
+	sort.Strings(symbols)
+
+	pairs := make([]string, len(symbols))
+	for i, s := range symbols {
+		pairs[i] = s + "=" + m.Key[s]
+	}
+	return strings.Join(pairs, " ")
+}
+
+// round is an opponent shape and the still encrypted response.
+type round struct {
+	opponent string
+	symbol   string
+}
+
+// InferMappings scores the guide under every injective mapping of its
+// second column symbols to the game's shapes and to its results, best
+// first. The first column is decrypted with the game's opponent key.
+func InferMappings(r io.Reader, g *Game) ([]Mapping, error) {
+	counts := map[round]int{}
+	symbols := []string{}
+	seen := map[string]bool{}
+
+	lineNum := 0
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		lineNum++
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, &parse.ParseError{Day: 2, Line: lineNum, Col: 1, Text: line, Cause: fmt.Errorf("want 2 columns, got %v", len(fields))}
+		}
+
+		opp, ok := g.opponentKey[fields[0]]
+		if !ok {
+			return nil, &parse.ParseError{Day: 2, Line: lineNum, Col: strings.Index(line, fields[0]) + 1, Text: line, Cause: fmt.Errorf("unknown opponent move %q", fields[0])}
+		}
+
+		counts[round{opponent: opp.name, symbol: fields[1]}]++
+		if !seen[fields[1]] {
+			seen[fields[1]] = true
+			symbols = append(symbols, fields[1])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.Strings(symbols)
+
+	mappings := []Mapping{}
+
+	shapes := g.Plays()
+	permutations(len(shapes), len(symbols), func(perm []int) {
+		m := Mapping{Interpretation: AsShape, Key: map[string]string{}}
+		for i, s := range symbols {
+			m.Key[s] = shapes[perm[i]].name
+		}
+		for rd, n := range counts {
+			m.Score += n * g.shootPartOne(g.byName[rd.opponent], g.byName[m.Key[rd.symbol]])
+		}
+		mappings = append(mappings, m)
+	})
+
+	results := []Result{Loss, Draw, Win}
+	var respondErr error
+	permutations(len(results), len(symbols), func(perm []int) {
+		m := Mapping{Interpretation: AsOutcome, Key: map[string]string{}}
+		for i, s := range symbols {
+			m.Key[s] = results[perm[i]]
+		}
+		for rd, n := range counts {
+			_, score, err := g.shootPartTwo(g.byName[rd.opponent], m.Key[rd.symbol])
+			if err != nil && respondErr == nil {
+				respondErr = err
+			}
+			m.Score += n * score
+		}
+		mappings = append(mappings, m)
+	})
+	if respondErr != nil {
+		return nil, respondErr
+	}
+
+	sort.SliceStable(mappings, func(i, j int) bool { return mappings[i].Score > mappings[j].Score })
+	return mappings, nil
+}
+
+// permutations calls fn with every ordered choice of k distinct values
+// from 0 to n-1, and not at all when k > n. fn must not keep perm.
+func permutations(n, k int, fn func(perm []int)) {
+	perm := make([]int, 0, k)
+	used := make([]bool, n)
+
+	var choose func()
+	choose = func() {
+		if len(perm) == k {
+			fn(perm)
+			return
+		}
+		for i := 0; i < n; i++ {
+			if used[i] {
+				continue
+			}
+			used[i] = true
+			perm = append(perm, i)
+			choose()
+			perm = perm[:len(perm)-1]
+			used[i] = false
+		}
+	}
+
+	if k <= n {
+		choose()
+	}
+}
+
+func init() {
+	solver.RegisterCommand(2, &decryptCommand{})
+}
+
+// decryptCommand ranks the possible keys for the second column.
+type decryptCommand struct {
+	rules  string
+	target int
+	top    int
+}
+
+func (*decryptCommand) Name() string { return "decrypt" }
+
+func (*decryptCommand) Synopsis() string {
+	return "rank every key for the second column, or find the keys giving a known score"
+}
+
+func (c *decryptCommand) SetFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.rules, "rules", "rps", "built-in rule set or path to a JSON rule file")
+	fs.IntVar(&c.target, "target", -1, "only show keys that give this total score")
+	fs.IntVar(&c.top, "top", 10, "show at most this many keys; 0 shows all")
+}
+
+func (c *decryptCommand) Run(in io.Reader, out io.Writer) error {
+	g, err := LoadGame(c.rules)
+	if err != nil {
+		return err
+	}
+
+	mappings, err := InferMappings(in, g)
+	if err != nil {
+		return err
+	}
+
+	if c.target >= 0 {
+		matching := []Mapping{}
+		for _, m := range mappings {
+			if m.Score == c.target {
+				matching = append(matching, m)
+			}
+		}
+		if len(matching) == 0 {
+			return fmt.Errorf("no key gives a score of %v", c.target)
+		}
+		mappings = matching
+	}
+
+	if c.top > 0 && len(mappings) > c.top {
+		mappings = mappings[:c.top]
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "rank\tscore\tas\tkey")
+	for i, m := range mappings {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", i+1, m.Score, m.Interpretation, m)
+	}
+	return w.Flush()
+}
@@ -1,170 +0,0 @@
-package main
-
-import (
-	"bufio"
-	"fmt"
-	"os"
-	"strings"
-)
-
-/*
---- Day 2: Rock Paper Scissors ---
-
-The Elves begin to set up camp on the beach. To decide whose tent gets to be closest to the snack storage, a giant Rock Paper Scissors tournament is already in progress.
-
-Rock Paper Scissors is a game between two players. Each game contains many rounds; in each round, the players each simultaneously choose one of Rock, Paper, or Scissors using a hand shape. Then, a winner for that round is selected: Rock defeats Scissors, Scissors defeats Paper, and Paper defeats Rock. If both players choose the same shape, the round instead ends in a draw.
-
-Appreciative of your help yesterday, one Elf gives you an encrypted strategy guide (your puzzle input) that they say will be sure to help you win. "The first column is what your opponent is going to play: A for Rock, B for Paper, and C for Scissors. The second column--" Suddenly, the Elf is called away to help with someone's tent.
-
-The second column, you reason, must be what you should play in response: X for Rock, Y for Paper, and Z for Scissors. Winning every time would be suspicious, so the responses must have been carefully chosen.
-
-The winner of the whole tournament is the player with the highest score. Your total score is the sum of your scores for each round. The score for a single round is the score for the shape you selected (1 for Rock, 2 for Paper, and 3 for Scissors) plus the score for the outcome of the round (0 if you lost, 3 if the round was a draw, and 6 if you won).
-
-Since you can't be sure if the Elf is trying to help you or trick you, you should calculate the score you would get if you were to follow the strategy guide.
-
-For example, suppose you were given the following strategy guide:
-
-A Y
-B X
-C Z
-
-This strategy guide predicts and recommends the following:
-
-    In the first round, your opponent will choose Rock (A), and you should choose Paper (Y). This ends in a win for you with a score of 8 (2 because you chose Paper + 6 because you won).
-    In the second round, your opponent will choose Paper (B), and you should choose Rock (X). This ends in a loss for you with a score of 1 (1 + 0).
-    The third round is a draw with both players choosing Scissors, giving you a score of 3 + 3 = 6.
-
-In this example, if you were to follow the strategy guide, you would get a total score of 15 (8 + 1 + 6).
-
-What would your total score be if everything goes exactly according to your strategy guide?
-
-https://adventofcode.com/2022/day/2
-*/
-
-type Play struct {
-	name  string
-	value int
-	beats string
-	loses string
-}
-
-type Result = string
-
-const (
-	LossValue = 0
-	DrawValue = 3
-	WinValue  = 6
-	Win       = Result("win")
-	Draw      = Result("draw")
-	Loss      = Result("loss")
-)
-
-var (
-	rock = Play{
-		name:  "Rock",
-		value: 1,
-		beats: "Scissors",
-		loses: "Paper",
-	}
-	paper = Play{
-		name:  "Paper",
-		value: 2,
-		beats: "Rock",
-		loses: "Scissors",
-	}
-	scissors = Play{
-		name:  "Scissors",
-		value: 3,
-		beats: "Paper",
-		loses: "Rock",
-	}
-	plays = map[string]Play{
-		"Rock":     rock,
-		"Paper":    paper,
-		"Scissors": scissors,
-	}
-)
-
-func shootPartOne(opponent, mine Play) int {
-
-	if opponent.beats == mine.name {
-		return LossValue + mine.value
-	} else if opponent.name == mine.name {
-		return DrawValue + mine.value
-	} else if mine.beats == opponent.name {
-		return WinValue + mine.value
-	}
-
-	panic("I should never get here")
-}
-
-func shootPartTwo(opponent Play, result Result) int {
-
-	switch result {
-	case Win:
-		myPlay := plays[opponent.loses]
-		return WinValue + myPlay.value
-
-	case Draw:
-		return DrawValue + opponent.value
-	case Loss:
-		myPlay := plays[opponent.beats]
-		return LossValue + myPlay.value
-	default:
-		panic("I should never get here.")
-	}
-
-}
-
-func main() {
-
-	part := 2
-
-	opponentDecryptKey := map[string]Play{
-		"A": rock,
-		"B": paper,
-		"C": scissors,
-	}
-
-	myDecryptKeyPartOne := map[string]Play{
-		"X": rock,
-		"Y": paper,
-		"Z": scissors,
-	}
-
-	myDecryptKeyPartTwo := map[string]Result{
-		"X": Loss,
-		"Y": Draw,
-		"Z": Win,
-	}
-
-	file, err := os.Open("day2/input.txt")
-	if err != nil {
-		panic(err)
-	}
-	defer file.Close()
-
-	var totalScore int
-	round := 1
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		plays := strings.Fields(line)
-		oppPlay := opponentDecryptKey[plays[0]]
-		if part == 1 {
-			myPlay := myDecryptKeyPartOne[plays[1]]
-			s := shootPartOne(oppPlay, myPlay)
-			fmt.Printf("Score For Round %v: %v\n", round, s)
-			totalScore += s
-		} else if part == 2 {
-			r := myDecryptKeyPartTwo[plays[1]]
-			s := shootPartTwo(oppPlay, r)
-			fmt.Printf("Score For Round %v: %v\n", round, s)
-			totalScore += s
-		}
-
-		round += 1
-	}
-
-	fmt.Printf("Total Score: %v\n", totalScore)
-}
@@ -0,0 +1,185 @@
+package day2
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/asc521/aoc-2022/solver"
+)
+
+// Report summarizes a guide played under one part's reading.
+type Report struct {
+	Rounds []Round
+
+	OpponentMoves map[string]int
+	MyMoves       map[string]int
+	Outcomes      map[Result]int
+	Total         int
+
+	// Cumulative is the running total after each round.
+	Cumulative []int
+
+	LongestWin, LongestLoss streak
+}
+
+// streak is a run of rounds with the same result. Start is the number of
+// its first round.
+type streak struct {
+	Start, Len int
+}
+
+func (s streak) String() string {
+	switch s.Len {
+	case 0:
+		return "0"
+	case 1:
+		return fmt.Sprintf("1 (round %v)", s.Start)
+	}
+	return fmt.Sprintf("%v (rounds %v-%v)", s.Len, s.Start, s.Start+s.Len-1)
+}
+
+// Analyze plays the guide for part and gathers a Report.
+func (g *Game) Analyze(r io.Reader, part int) (Report, error) {
+	rep := Report{
+		OpponentMoves: map[string]int{},
+		MyMoves:       map[string]int{},
+		Outcomes:      map[Result]int{},
+	}
+
+	var current streak
+	var currentResult Result
+	err := g.eachRound(r, part, func(rd Round) error {
+		rep.Rounds = append(rep.Rounds, rd)
+		rep.OpponentMoves[rd.Opponent.name]++
+		rep.MyMoves[rd.Mine.name]++
+		rep.Outcomes[rd.Result]++
+		rep.Total += rd.Score
+		rep.Cumulative = append(rep.Cumulative, rep.Total)
+
+		if rd.Result != currentResult {
+			current = streak{Start: rd.Number}
+			currentResult = rd.Result
+		}
+		current.Len++
+
+		switch {
+		case rd.Result == Win && current.Len > rep.LongestWin.Len:
+			rep.LongestWin = current
+		case rd.Result == Loss && current.Len > rep.LongestLoss.Len:
+			rep.LongestLoss = current
+		}
+		return nil
+	})
+	return rep, err
+}
+
+// sparkLevels are the characters of a sparkline from lowest to highest.
+const sparkLevels = "_.-:=+*#%@"
+
+// Sparkline draws values as a line of width characters, each the last
+// value of its share of the series scaled between the smallest and largest
+// value. Series shorter than width get one character per value.
+func Sparkline(values []int, width int) string {
+	if len(values) == 0 || width < 1 {
+		return ""
+	}
+	if width > len(values) {
+		width = len(values)
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+
+	var b strings.Builder
+	for i := 0; i < width; i++ {
+		v := values[(i+1)*len(values)/width-1]
+		level := len(sparkLevels) - 1
+		if hi > lo {
+			level = (v - lo) * (len(sparkLevels) - 1) / (hi - lo)
+		}
+		b.WriteByte(sparkLevels[level])
+	}
+	return b.String()
+}
+
+func init() {
+	solver.RegisterCommand(2, &reportCommand{})
+}
+
+// reportCommand prints a Report.
+type reportCommand struct {
+	rules   string
+	part    int
+	width   int
+	verbose bool
+}
+
+func (*reportCommand) Name() string { return "report" }
+
+func (*reportCommand) Synopsis() string {
+	return "summarize the moves, outcomes, score over time and streaks of the guide"
+}
+
+func (c *reportCommand) SetFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.rules, "rules", "rps", "built-in rule set or path to a JSON rule file")
+	fs.IntVar(&c.part, "part", 1, "read the second column as part 1 (shapes) or part 2 (results) does")
+	fs.IntVar(&c.width, "width", 60, "width of the score sparkline")
+	fs.BoolVar(&c.verbose, "v", false, "also list every round")
+}
+
+func (c *reportCommand) Run(in io.Reader, out io.Writer) error {
+	g, err := LoadGame(c.rules)
+	if err != nil {
+		return err
+	}
+
+	rep, err := g.Analyze(in, c.part)
+	if err != nil {
+		return err
+	}
+
+	if c.verbose {
+		for _, rd := range rep.Rounds {
+			fmt.Fprintf(out, "Score For Round %v: %v (%v vs %v, %v)\n", rd.Number, rd.Score, rd.Mine, rd.Opponent, rd.Result)
+		}
+		fmt.Fprintln(out)
+	}
+
+	moves := func(counts map[string]int) string {
+		parts := []string{}
+		for _, p := range g.plays {
+			parts = append(parts, fmt.Sprintf("%v %v", p.name, counts[p.name]))
+		}
+		return strings.Join(parts, "  ")
+	}
+
+	outcomes := []string{}
+	for _, r := range []Result{Win, Draw, Loss} {
+		pct := 0.0
+		if len(rep.Rounds) > 0 {
+			pct = 100 * float64(rep.Outcomes[r]) / float64(len(rep.Rounds))
+		}
+		outcomes = append(outcomes, fmt.Sprintf("%v %v (%.1f%%)", r, rep.Outcomes[r], pct))
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "rounds\t%v\n", len(rep.Rounds))
+	fmt.Fprintf(w, "total score\t%v\n", rep.Total)
+	fmt.Fprintf(w, "opponent\t%v\n", moves(rep.OpponentMoves))
+	fmt.Fprintf(w, "mine\t%v\n", moves(rep.MyMoves))
+	fmt.Fprintf(w, "outcomes\t%v\n", strings.Join(outcomes, "  "))
+	fmt.Fprintf(w, "longest win streak\t%v\n", rep.LongestWin)
+	fmt.Fprintf(w, "longest loss streak\t%v\n", rep.LongestLoss)
+	fmt.Fprintf(w, "score over time\t|%v| %v\n", Sparkline(rep.Cumulative, c.width), rep.Total)
+	return w.Flush()
+}
@@ -0,0 +1,280 @@
+package day2
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/asc521/aoc-2022/solver"
+)
+
+// Built-in rule sets, selectable by name.
+//
+//go:embed rules/*.json
+var builtinRules embed.FS
+
+// Rules is the configuration of a game in the rock paper scissors family
+// and of how a strategy guide is encrypted for it.
+type Rules struct {
+	Shapes []struct {
+		Name  string `json:"name"`
+		Score int    `json:"score"`
+	} `json:"shapes"`
+
+	// Beats lists, for each shape, the shapes it defeats.
+	Beats map[string][]string `json:"beats"`
+
+	// Outcomes scores each Result. Without it the classic WinValue,
+	// DrawValue and LossValue are used.
+	Outcomes map[Result]int `json:"outcomes"`
+
+	// OpponentKey decrypts the first column of the guide, ResponseKey the
+	// second column as a shape (part one) and OutcomeKey the second column
+	// as a result (part two).
+	OpponentKey map[string]string `json:"opponent_key"`
+	ResponseKey map[string]string `json:"response_key"`
+	OutcomeKey  map[string]Result `json:"outcome_key"`
+}
+
+// Game is a validated rule set.
+type Game struct {
+	plays    []Play
+	byName   map[string]Play
+	outcomes map[Result]int
+
+	opponentKey map[string]Play
+	responseKey map[string]Play
+	outcomeKey  map[string]Result
+}
+
+// Classic is the puzzle's Rock Paper Scissors.
+var Classic = mustBuiltin("rps")
+
+func mustBuiltin(name string) *Game {
+	g, err := LoadGame(name)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// BuiltinGames returns the names LoadGame accepts besides file paths.
+func BuiltinGames() []string {
+	entries, _ := builtinRules.ReadDir("rules")
+	names := []string{}
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	return names
+}
+
+// LoadGame returns a built-in game such as "rps" or "rpsls", or reads the
+// rules from the JSON file at name.
+func LoadGame(name string) (*Game, error) {
+	f, err := builtinRules.Open(path.Join("rules", name+".json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		f, err = os.Open(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	g, err := ReadRules(f)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", name, err)
+	}
+	return g, nil
+}
+
+// ReadRules decodes and validates a JSON rule set.
+func ReadRules(r io.Reader) (*Game, error) {
+	var rules Rules
+	if err := json.NewDecoder(r).Decode(&rules); err != nil {
+		return nil, fmt.Errorf("day2: rules: %w", err)
+	}
+	return NewGame(rules)
+}
+
+// NewGame validates rules. Every shape must beat exactly half of the
+// others and lose to the rest, which needs an odd number of shapes; rule
+// sets that favor a shape are rejected.
+func NewGame(rules Rules) (*Game, error) {
+	n := len(rules.Shapes)
+	if n < 3 || n%2 == 0 {
+		return nil, fmt.Errorf("day2: rules: need an odd number of shapes, at least 3, got %v", n)
+	}
+
+	g := &Game{
+		byName:      map[string]Play{},
+		outcomes:    map[Result]int{},
+		opponentKey: map[string]Play{},
+		responseKey: map[string]Play{},
+		outcomeKey:  map[string]Result{},
+	}
+
+	for _, s := range rules.Shapes {
+		if s.Name == "" {
+			return nil, errors.New("day2: rules: shape without a name")
+		}
+		if _, dup := g.byName[s.Name]; dup {
+			return nil, fmt.Errorf("day2: rules: shape %q defined twice", s.Name)
+		}
+		g.byName[s.Name] = Play{name: s.Name, value: s.Score}
+	}
+
+	for name, beaten := range rules.Beats {
+		p, ok := g.byName[name]
+		if !ok {
+			return nil, fmt.Errorf("day2: rules: beats: unknown shape %q", name)
+		}
+		seen := map[string]bool{}
+		for _, b := range beaten {
+			if seen[b] {
+				return nil, fmt.Errorf("day2: rules: %v beats %v twice", name, b)
+			}
+			seen[b] = true
+			if _, ok := g.byName[b]; !ok {
+				return nil, fmt.Errorf("day2: rules: %v beats unknown shape %q", name, b)
+			}
+			if b == name {
+				return nil, fmt.Errorf("day2: rules: %v beats itself", name)
+			}
+			p.beats = append(p.beats, b)
+		}
+		g.byName[name] = p
+	}
+
+	for _, s := range rules.Shapes {
+		p := g.byName[s.Name]
+		if len(p.beats) != (n-1)/2 {
+			return nil, fmt.Errorf("day2: rules: unbalanced: %v beats %v shapes, want %v", p.name, len(p.beats), (n-1)/2)
+		}
+		g.plays = append(g.plays, p)
+	}
+
+	// Every two distinct shapes need exactly one winner, or some result
+	// has no shape to respond with.
+	for i, a := range g.plays {
+		for _, b := range g.plays[i+1:] {
+			switch {
+			case a.Beats(b) && b.Beats(a):
+				return nil, fmt.Errorf("day2: rules: %v and %v beat each other", a.name, b.name)
+			case !a.Beats(b) && !b.Beats(a):
+				return nil, fmt.Errorf("day2: rules: neither %v nor %v beats the other", a.name, b.name)
+			}
+		}
+	}
+
+	outcomes := rules.Outcomes
+	if outcomes == nil {
+		outcomes = map[Result]int{Win: WinValue, Draw: DrawValue, Loss: LossValue}
+	}
+	for _, r := range []Result{Win, Draw, Loss} {
+		score, ok := outcomes[r]
+		if !ok {
+			return nil, fmt.Errorf("day2: rules: no score for %v", r)
+		}
+		g.outcomes[r] = score
+	}
+
+	for code, name := range rules.OpponentKey {
+		p, ok := g.byName[name]
+		if !ok {
+			return nil, fmt.Errorf("day2: rules: opponent key %v: unknown shape %q", code, name)
+		}
+		g.opponentKey[code] = p
+	}
+	for code, name := range rules.ResponseKey {
+		p, ok := g.byName[name]
+		if !ok {
+			return nil, fmt.Errorf("day2: rules: response key %v: unknown shape %q", code, name)
+		}
+		g.responseKey[code] = p
+	}
+	for code, r := range rules.OutcomeKey {
+		if _, ok := g.outcomes[r]; !ok {
+			return nil, fmt.Errorf("day2: rules: outcome key %v: unknown result %q", code, r)
+		}
+		g.outcomeKey[code] = r
+	}
+
+	return g, nil
+}
+
+// Plays returns the shapes of the game in the order they were defined.
+func (g *Game) Plays() []Play {
+	return append([]Play(nil), g.plays...)
+}
+
+// Outcome returns the result of mine against opponent, from my side.
+func (g *Game) Outcome(opponent, mine Play) Result {
+	switch {
+	case mine.Beats(opponent):
+		return Win
+	case opponent.Beats(mine):
+		return Loss
+	default:
+		return Draw
+	}
+}
+
+// Respond returns the shape that gets result against opponent. When
+// several do, the one scoring most is chosen, and the first defined among
+// equals. It is an error if none does.
+func (g *Game) Respond(opponent Play, result Result) (Play, error) {
+	candidates := []Play{}
+	for _, p := range g.plays {
+		if g.Outcome(opponent, p) == result {
+			candidates = append(candidates, p)
+		}
+	}
+	if len(candidates) == 0 {
+		return Play{}, fmt.Errorf("day2: no shape gets a %v against %v", result, opponent)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].value > candidates[j].value })
+	return candidates[0], nil
+}
+
+func init() {
+	solver.RegisterCommand(2, &scoreCommand{})
+}
+
+// scoreCommand scores the guide under another rule set.
+type scoreCommand struct {
+	rules string
+	part  int
+}
+
+func (*scoreCommand) Name() string { return "score" }
+
+func (*scoreCommand) Synopsis() string {
+	return "score the guide under a built-in or custom rule set"
+}
+
+func (c *scoreCommand) SetFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.rules, "rules", "rps", fmt.Sprintf("built-in rule set (%v) or path to a JSON rule file", strings.Join(BuiltinGames(), ", ")))
+	fs.IntVar(&c.part, "part", 1, "part to solve (1 or 2)")
+}
+
+func (c *scoreCommand) Run(in io.Reader, out io.Writer) error {
+	g, err := LoadGame(c.rules)
+	if err != nil {
+		return err
+	}
+
+	answer, err := solver.Solve(Solver{Game: g}, c.part, in)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, answer)
+	return err
+}
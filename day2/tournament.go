@@ -0,0 +1,298 @@
+package day2
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/asc521/aoc-2022/parse"
+	"github.com/asc521/aoc-2022/solver"
+)
+
+// Strategy picks a shape every round of a match.
+type Strategy interface {
+	Name() string
+
+	// Throw returns the shape for a round. opponent holds the other
+	// side's earlier throws, oldest first, and rng is the match's source
+	// of randomness.
+	Throw(g *Game, opponent []Play, rng *rand.Rand) Play
+}
+
+// FollowGuide throws the second column of a strategy guide in order,
+// starting over when it runs out.
+type FollowGuide struct {
+	Moves []Play
+}
+
+func (*FollowGuide) Name() string { return "guide" }
+
+func (s *FollowGuide) Throw(g *Game, opponent []Play, rng *rand.Rand) Play {
+	if len(s.Moves) == 0 {
+		return Random{}.Throw(g, opponent, rng)
+	}
+	return s.Moves[len(opponent)%len(s.Moves)]
+}
+
+// Random throws any shape with equal chance.
+type Random struct{}
+
+func (Random) Name() string { return "random" }
+
+func (Random) Throw(g *Game, opponent []Play, rng *rand.Rand) Play {
+	return g.plays[rng.Intn(len(g.plays))]
+}
+
+// Frequency counts the opponent's throws and plays to beat the most
+// common one, which is the earliest defined among equals.
+type Frequency struct{}
+
+func (Frequency) Name() string { return "frequency" }
+
+func (Frequency) Throw(g *Game, opponent []Play, rng *rand.Rand) Play {
+	if len(opponent) == 0 {
+		return Random{}.Throw(g, opponent, rng)
+	}
+
+	counts := map[string]int{}
+	for _, p := range opponent {
+		counts[p.name]++
+	}
+
+	common := g.plays[0]
+	for _, p := range g.plays[1:] {
+		if counts[p.name] > counts[common.name] {
+			common = p
+		}
+	}
+	return beat(g, common, rng)
+}
+
+// BeatLast plays to beat the opponent's previous throw.
+type BeatLast struct{}
+
+func (BeatLast) Name() string { return "beat-last" }
+
+func (BeatLast) Throw(g *Game, opponent []Play, rng *rand.Rand) Play {
+	if len(opponent) == 0 {
+		return Random{}.Throw(g, opponent, rng)
+	}
+	return beat(g, opponent[len(opponent)-1], rng)
+}
+
+// beat returns the best shape against p. NewGame guarantees there is one,
+// but a random throw stands in rather than failing a match.
+func beat(g *Game, p Play, rng *rand.Rand) Play {
+	mine, err := g.Respond(p, Win)
+	if err != nil {
+		return Random{}.Throw(g, nil, rng)
+	}
+	return mine
+}
+
+// ReadGuide decrypts the second column of a strategy guide into shapes
+// with the game's response key.
+func ReadGuide(r io.Reader, g *Game) ([]Play, error) {
+	moves := []Play{}
+	lineNum := 0
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		lineNum++
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, &parse.ParseError{Day: 2, Line: lineNum, Col: 1, Text: line, Cause: fmt.Errorf("want 2 columns, got %v", len(fields))}
+		}
+
+		p, ok := g.responseKey[fields[1]]
+		if !ok {
+			return nil, &parse.ParseError{Day: 2, Line: lineNum, Col: strings.LastIndex(line, fields[1]) + 1, Text: line, Cause: fmt.Errorf("unknown response %q", fields[1])}
+		}
+		moves = append(moves, p)
+	}
+	return moves, scanner.Err()
+}
+
+// Tournament plays every pair of strategies against each other for Rounds
+// rounds, once per seed.
+type Tournament struct {
+	Game       *Game
+	Strategies []Strategy
+	Rounds     int
+	Seeds      []int64
+}
+
+// Standing is how one strategy did over all its matches.
+type Standing struct {
+	Strategy            string
+	Matches             int
+	Wins, Draws, Losses int
+	// Scores holds the strategy's total score in each match.
+	Scores []int
+}
+
+// Rounds returns the number of rounds the strategy played.
+func (s Standing) Rounds() int {
+	return s.Wins + s.Draws + s.Losses
+}
+
+// Rate returns n as a fraction of the rounds played.
+func (s Standing) Rate(n int) float64 {
+	if s.Rounds() == 0 {
+		return 0
+	}
+	return float64(n) / float64(s.Rounds())
+}
+
+// Run plays the round robin and returns the standings in the order of
+// Strategies.
+func (t Tournament) Run() []Standing {
+	standings := make([]Standing, len(t.Strategies))
+	for i, s := range t.Strategies {
+		standings[i].Strategy = s.Name()
+	}
+
+	for i := range t.Strategies {
+		for j := i + 1; j < len(t.Strategies); j++ {
+			for _, seed := range t.Seeds {
+				t.match(&standings[i], &standings[j], t.Strategies[i], t.Strategies[j], seed)
+			}
+		}
+	}
+	return standings
+}
+
+func (t Tournament) match(sa, sb *Standing, a, b Strategy, seed int64) {
+	rng := rand.New(rand.NewSource(seed))
+	g := t.Game
+
+	var historyA, historyB []Play
+	scoreA, scoreB := 0, 0
+	for round := 0; round < t.Rounds; round++ {
+		pa := a.Throw(g, historyB, rng)
+		pb := b.Throw(g, historyA, rng)
+		historyA = append(historyA, pa)
+		historyB = append(historyB, pb)
+
+		scoreA += g.shootPartOne(pb, pa)
+		scoreB += g.shootPartOne(pa, pb)
+
+		switch g.Outcome(pb, pa) {
+		case Win:
+			sa.Wins++
+			sb.Losses++
+		case Loss:
+			sa.Losses++
+			sb.Wins++
+		default:
+			sa.Draws++
+			sb.Draws++
+		}
+	}
+
+	sa.Matches++
+	sb.Matches++
+	sa.Scores = append(sa.Scores, scoreA)
+	sb.Scores = append(sb.Scores, scoreB)
+}
+
+// quantile returns the value at fraction q of the sorted scores, by the
+// nearest rank.
+func quantile(sorted []int, q float64) int {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[int(q*float64(len(sorted)-1)+0.5)]
+}
+
+func init() {
+	solver.RegisterCommand(2, &tournamentCommand{})
+}
+
+// tournamentCommand runs a Tournament and prints the standings.
+type tournamentCommand struct {
+	rules      string
+	strategies string
+	rounds     int
+	seed       int64
+	seeds      int
+}
+
+func (*tournamentCommand) Name() string { return "tournament" }
+
+func (*tournamentCommand) Synopsis() string {
+	return "play strategies against each other in a seeded round robin"
+}
+
+func (c *tournamentCommand) SetFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.rules, "rules", "rps", "built-in rule set or path to a JSON rule file")
+	fs.StringVar(&c.strategies, "strategies", "guide,random,frequency,beat-last", "comma-separated strategies to enter")
+	fs.IntVar(&c.rounds, "rounds", 1000, "rounds per match")
+	fs.Int64Var(&c.seed, "seed", 1, "seed of the first match of each pairing")
+	fs.IntVar(&c.seeds, "seeds", 10, "matches per pairing, each with the next seed")
+}
+
+func (c *tournamentCommand) Run(in io.Reader, out io.Writer) error {
+	g, err := LoadGame(c.rules)
+	if err != nil {
+		return err
+	}
+
+	t := Tournament{Game: g, Rounds: c.rounds}
+	for i := 0; i < c.seeds; i++ {
+		t.Seeds = append(t.Seeds, c.seed+int64(i))
+	}
+
+	if t.Strategies, err = parseStrategies(c.strategies, in, g); err != nil {
+		return err
+	}
+	if len(t.Strategies) < 2 {
+		return fmt.Errorf("need at least 2 strategies, got %v", len(t.Strategies))
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "strategy\tmatches\twin\tdraw\tloss\tmin\tp25\tmedian\tp75\tmax\t")
+	for _, s := range t.Run() {
+		scores := append([]int(nil), s.Scores...)
+		sort.Ints(scores)
+		fmt.Fprintf(w, "%v\t%v\t%.1f%%\t%.1f%%\t%.1f%%\t%v\t%v\t%v\t%v\t%v\t\n",
+			s.Strategy, s.Matches,
+			100*s.Rate(s.Wins), 100*s.Rate(s.Draws), 100*s.Rate(s.Losses),
+			quantile(scores, 0), quantile(scores, 0.25), quantile(scores, 0.5), quantile(scores, 0.75), quantile(scores, 1))
+	}
+	return w.Flush()
+}
+
+// parseStrategies builds the entrants named in a comma-separated list. The
+// guide is read from in once, however many guide entrants there are.
+func parseStrategies(list string, in io.Reader, g *Game) ([]Strategy, error) {
+	var strategies []Strategy
+	var guide []Play
+	for _, name := range strings.Split(list, ",") {
+		switch strings.TrimSpace(name) {
+		case "guide":
+			if guide == nil {
+				moves, err := ReadGuide(in, g)
+				if err != nil {
+					return nil, err
+				}
+				guide = moves
+			}
+			strategies = append(strategies, &FollowGuide{Moves: guide})
+		case "random":
+			strategies = append(strategies, Random{})
+		case "frequency":
+			strategies = append(strategies, Frequency{})
+		case "beat-last":
+			strategies = append(strategies, BeatLast{})
+		default:
+			return nil, fmt.Errorf("unknown strategy %q (want guide, random, frequency or beat-last)", name)
+		}
+	}
+	return strategies, nil
+}
@@ -0,0 +1,230 @@
+package day3
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/asc521/aoc-2022/logger"
+	"github.com/asc521/aoc-2022/parse"
+	"github.com/asc521/aoc-2022/solver"
+)
+
+/*
+--- Day 3: Rucksack Reorganization ---
+
+One Elf has the important job of loading all of the rucksacks with supplies for the jungle journey. Unfortunately, that Elf didn't quite follow the packing instructions, and so a few items now need to be rearranged.
+
+Each rucksack has two large compartments. All items of a given type are meant to go into exactly one of the two compartments. The Elf that did the packing failed to follow this rule for exactly one item type per rucksack.
+
+The Elves have made a list of all of the items currently in each rucksack (your puzzle input), but they need your help finding the errors. Every item type is identified by a single lowercase or uppercase letter (that is, a and A refer to different types of items).
+
+The list of items for each rucksack is given as characters all on a single line. A given rucksack always has the same number of items in each of its two compartments, so the first half of the characters represent items in the first compartment, while the second half of the characters represent items in the second compartment.
+
+For example, suppose you have the following list of contents from six rucksacks:
+
+vJrwpWtwJgWrhcsFMMfFFhFp
+jqHRNqRjqzjGDLGLrsFMfFZSrLrFZsSL
+PmmdzqPrVvPwwTWBwg
+wMqvLMZHhHMvwLHjbvcjnnSBnvTQFn
+ttgJtRGJQctTZtZT
+CrZsJsPPZsGzwwsLwLmpwMDw
+
+    The first rucksack contains the items vJrwpWtwJgWrhcsFMMfFFhFp, which means its first compartment contains the items vJrwpWtwJgWr, while the second compartment contains the items hcsFMMfFFhFp. The only item type that appears in both compartments is lowercase p.
+    The second rucksack's compartments contain jqHRNqRjqzjGDLGL and rsFMfFZSrLrFZsSL. The only item type that appears in both compartments is uppercase L.
+    The third rucksack's compartments contain PmmdzqPrV and vPwwTWBwg; the only common item type is uppercase P.
+    The fourth rucksack's compartments only share item type v.
+    The fifth rucksack's compartments only share item type t.
+    The sixth rucksack's compartments only share item type s.
+
+To help prioritize item rearrangement, every item type can be converted to a priority:
+
+    Lowercase item types a through z have priorities 1 through 26.
+    Uppercase item types A through Z have priorities 27 through 52.
+
+In the above example, the priority of the item type that appears in both compartments of each rucksack is 16 (p), 38 (L), 42 (P), 22 (v), 20 (t), and 19 (s); the sum of these is 157.
+
+Find the item type that appears in both compartments of each rucksack. What is the sum of the priorities of those item types?
+
+*/
+
+// checkRucksack reports the first character in line that is not an item type.
+func checkRucksack(scheme *PriorityScheme, line string, lineNum int) error {
+	if line == "" {
+		return &parse.ParseError{Day: 3, Line: lineNum, Text: line, Cause: errors.New("empty rucksack")}
+	}
+
+	col := 1
+	for _, ch := range line {
+		if !scheme.Valid(ch) {
+			return &parse.ParseError{Day: 3, Line: lineNum, Col: col, Text: line, Cause: fmt.Errorf("invalid item type %q", ch)}
+		}
+		col++
+	}
+	return nil
+}
+
+func init() {
+	solver.Register(3, Solver{})
+}
+
+// Solver answers day 3 from a rucksack inventory. GroupSize is the number
+// of elves sharing a badge in part two, 3 when zero, and Scheme the item
+// priorities, AoC when nil.
+type Solver struct {
+	GroupSize int
+	Scheme    *PriorityScheme
+}
+
+func (s Solver) PartOne(r io.Reader) (string, error) {
+	return totalPriority(r, s.scheme(), 1, 1)
+}
+
+func (s Solver) PartTwo(r io.Reader) (string, error) {
+	groupSize := s.GroupSize
+	if groupSize == 0 {
+		groupSize = 3
+	}
+	return totalPriority(r, s.scheme(), 2, groupSize)
+}
+
+func (s Solver) scheme() *PriorityScheme {
+	if s.Scheme == nil {
+		return AoC
+	}
+	return s.Scheme
+}
+
+// totalPriority sums the priorities of the item shared by the two
+// compartments of every rucksack in part 1, or by each group of groupSize
+// rucksacks in part 2. Where several items are shared the lowest priority
+// one counts.
+func totalPriority(r io.Reader, scheme *PriorityScheme, part, groupSize int) (string, error) {
+	if part == 1 {
+		groupSize = 1
+	} else if groupSize < 2 {
+		return "", fmt.Errorf("day3: group size must be at least 2, got %v", groupSize)
+	}
+
+	chunks, err := parse.Chunks(r, groupSize)
+	if err != nil {
+		var pe *parse.ParseError
+		if errors.As(err, &pe) {
+			pe.Day = 3
+		}
+		return "", err
+	}
+
+	rucksack := 1
+	totalP := 0
+	for _, chunk := range chunks {
+		for i, line := range chunk.Lines {
+			if err := checkRucksack(scheme, line, chunk.Line+i); err != nil {
+				return "", err
+			}
+		}
+
+		if part == 1 {
+			fullInventory := []rune(chunk.Lines[0])
+			if len(fullInventory)%2 != 0 {
+				return "", &parse.ParseError{Day: 3, Line: chunk.Line, Text: chunk.Lines[0], Cause: fmt.Errorf("odd number of items (%v) cannot fill two compartments", len(fullInventory))}
+			}
+			compartmentOne := string(fullInventory[0 : len(fullInventory)/2])
+			compartmentTwo := string(fullInventory[len(fullInventory)/2:])
+			b := scheme.Intersect(compartmentOne, compartmentTwo).Lowest()
+			if b == 0 {
+				return "", &parse.ParseError{Day: 3, Line: chunk.Line, Col: len(fullInventory)/2 + 1, Text: chunk.Lines[0], Cause: errors.New("compartments share no item type")}
+			}
+			p := scheme.Priority(b)
+			totalP += p
+			logger.Infof("Rucksack %v: %c  %v", rucksack, scheme.Item(b), p)
+			rucksack += 1
+		} else {
+			elves := chunk.Lines
+			b := scheme.Intersect(elves...).Lowest()
+			if b == 0 {
+				last := len(elves) - 1
+				return "", &parse.ParseError{Day: 3, Line: chunk.Line + last, Text: elves[last], Cause: fmt.Errorf("group of %v has no common badge", groupSize)}
+			}
+			totalP += scheme.Priority(b)
+		}
+	}
+
+	return strconv.Itoa(totalP), nil
+}
+
+func init() {
+	solver.RegisterCommand(3, &badgesCommand{})
+}
+
+// badgesCommand solves part two for groups of any size.
+type badgesCommand struct {
+	groupSize int
+	scheme    string
+}
+
+func (*badgesCommand) Name() string { return "badges" }
+
+func (*badgesCommand) Synopsis() string {
+	return "sum the badge priorities of groups of any size"
+}
+
+func (c *badgesCommand) SetFlags(fs *flag.FlagSet) {
+	fs.IntVar(&c.groupSize, "group-size", 3, "number of elves sharing a badge")
+	schemeFlag(fs, &c.scheme)
+}
+
+func (c *badgesCommand) Run(in io.Reader, out io.Writer) error {
+	scheme, err := LoadScheme(c.scheme)
+	if err != nil {
+		return err
+	}
+
+	answer, err := Solver{GroupSize: c.groupSize, Scheme: scheme}.PartTwo(in)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, answer)
+	return err
+}
+
+func init() {
+	solver.RegisterCommand(3, &scoreCommand{})
+}
+
+// scoreCommand solves either part under another priority scheme.
+type scoreCommand struct {
+	part   int
+	scheme string
+}
+
+func (*scoreCommand) Name() string { return "score" }
+
+func (*scoreCommand) Synopsis() string {
+	return "solve a part with a custom priority scheme"
+}
+
+func (c *scoreCommand) SetFlags(fs *flag.FlagSet) {
+	fs.IntVar(&c.part, "part", 1, "part to solve (1 or 2)")
+	schemeFlag(fs, &c.scheme)
+}
+
+func (c *scoreCommand) Run(in io.Reader, out io.Writer) error {
+	scheme, err := LoadScheme(c.scheme)
+	if err != nil {
+		return err
+	}
+
+	answer, err := solver.Solve(Solver{Scheme: scheme}, c.part, in)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, answer)
+	return err
+}
+
+func schemeFlag(fs *flag.FlagSet, spec *string) {
+	fs.StringVar(spec, "scheme", "aoc", `item priorities: aoc, "order:" and the item types from lowest to highest, or a JSON file`)
+}
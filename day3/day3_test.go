@@ -0,0 +1,230 @@
+package day3
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/asc521/aoc-2022/parse"
+	"github.com/asc521/aoc-2022/solver"
+	"github.com/asc521/aoc-2022/solver/solvertest"
+)
+
+func TestSolver(t *testing.T) {
+	solvertest.Run(t, Solver{}, []solvertest.Case{
+		{Name: "example part 1", File: "rucksack_inventory.txt", Part: 1, Want: "157"},
+		{Name: "example part 2", File: "rucksack_inventory.txt", Part: 2, Want: "70"},
+	})
+}
+
+func TestIntersect(t *testing.T) {
+	tests := []struct {
+		groups []string
+		want   []int
+	}{
+		{nil, []int{}},
+		{[]string{"vJrwpWtwJgWr", "hcsFMMfFFhFp"}, []int{16}},
+		{[]string{"abcA", "bcA", "cAb", "xAcb"}, []int{2, 3, 27}},
+		{[]string{"abc", "def"}, []int{}},
+	}
+	for _, tt := range tests {
+		if got := AoC.Intersect(tt.groups...).Bits(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Intersect(%q) = %v, want %v", tt.groups, got, tt.want)
+		}
+	}
+}
+
+func TestGroupSize(t *testing.T) {
+	solvertest.Run(t, Solver{GroupSize: 2}, []solvertest.Case{
+		{Name: "pairs", Input: "abX\ncdX\nefY\nghY\n", Part: 2, Want: "101"},
+	})
+}
+
+func TestNothingShared(t *testing.T) {
+	tests := []struct {
+		part      int
+		input     string
+		line, col int
+	}{
+		{1, "aAaA\nabcdef\n", 2, 4},
+		{2, "abc\nade\nxyz\n", 3, 0},
+	}
+
+	for _, tt := range tests {
+		_, err := solver.Solve(Solver{}, tt.part, strings.NewReader(tt.input))
+		var pe *parse.ParseError
+		if !errors.As(err, &pe) || pe.Line != tt.line || pe.Col != tt.col || pe.Text == "" {
+			t.Errorf("part %v of %q: got %v, want a parse error at %v:%v", tt.part, tt.input, err, tt.line, tt.col)
+		}
+	}
+}
+
+// mapIntersect is the map based intersection day 3 used before ItemSet,
+// kept to compare against in the benchmarks.
+func mapIntersect(groups ...string) string {
+	counts := map[string]int{}
+	for _, g := range groups {
+		seen := map[string]bool{}
+		for _, s := range strings.Split(g, "") {
+			if !seen[s] {
+				seen[s] = true
+				counts[s]++
+			}
+		}
+	}
+	for s, n := range counts {
+		if n == len(groups) {
+			return s
+		}
+	}
+	return ""
+}
+
+// largeInventory repeats the example until it is 30,000 rucksacks long.
+func largeInventory(b *testing.B) []byte {
+	example, err := os.ReadFile("rucksack_inventory.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	if !bytes.HasSuffix(example, []byte("\n")) {
+		example = append(example, '\n')
+	}
+	return bytes.Repeat(example, 5000)
+}
+
+func BenchmarkIntersect(b *testing.B) {
+	lines := strings.Fields(string(largeInventory(b)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j+3 <= len(lines); j += 3 {
+			Intersect(lines[j : j+3]...)
+		}
+	}
+}
+
+func BenchmarkIntersectMap(b *testing.B) {
+	lines := strings.Fields(string(largeInventory(b)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j+3 <= len(lines); j += 3 {
+			mapIntersect(lines[j : j+3]...)
+		}
+	}
+}
+
+func BenchmarkPartOne(b *testing.B) {
+	input := largeInventory(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := (Solver{}).PartOne(bytes.NewReader(input)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPartTwo(b *testing.B) {
+	input := largeInventory(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := (Solver{}).PartTwo(bytes.NewReader(input)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	f, err := os.Open("rucksack_inventory.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	rep, err := Validate(f, AoC, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rep.Rucksacks != 6 || rep.Groups != 2 || len(rep.Violations) != 0 {
+		t.Errorf("example: %+v, want 6 rucksacks, 2 groups and no violations", rep)
+	}
+
+	rep, err = Validate(strings.NewReader("abcabc\nabé\n\nxyQaQb\n"), AoC, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := []string{}
+	for _, v := range rep.Violations {
+		got = append(got, fmt.Sprintf("%v:%v %v %v", v.Line, v.Col, v.Kind, v.Items))
+	}
+	want := []string{
+		"1:0 several-shared-items abc",
+		"2:0 odd-length ",
+		"2:3 invalid-item é",
+		"1:0 several-badges ab",
+		"3:0 empty-rucksack ",
+		"3:0 no-badge ",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("violations:\n%v\nwant\n%v", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestSchemes(t *testing.T) {
+	ordered, err := OrderedScheme("0123456789äöüß")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapped, err := ReadScheme(strings.NewReader(`{"é": 5, "ñ": 2, "x": 5}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		scheme *PriorityScheme
+		part   int
+		input  string
+		want   string
+	}{
+		{"digits", ordered, 1, "1221\n9ßß0\n", "16"},
+		{"accents", ordered, 1, "äüöü\n", "13"},
+		{"group", ordered, 2, "ä01\nä23\näß\n", "11"},
+		{"ties-by-rune", mapped, 1, "éxxé\n", "5"},
+		{"map", mapped, 1, "ñéñx\n", "2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := solver.Solve(Solver{Scheme: tt.scheme}, tt.part, strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := (Solver{Scheme: ordered}).PartOne(strings.NewReader("abab\n")); err == nil {
+		t.Error("items outside the scheme were accepted")
+	}
+
+	// The compartments share nothing, which must not score as priority 0.
+	for part, input := range map[int]string{1: "1212\näöüß\n", 2: "ä01\nä23\nß9\n"} {
+		_, err := solver.Solve(Solver{Scheme: ordered}, part, strings.NewReader(input))
+		var pe *parse.ParseError
+		if !errors.As(err, &pe) || pe.Line != 2+part-1 {
+			t.Errorf("part %v of %q: got %v, want a parse error on line %v", part, input, err, 2+part-1)
+		}
+	}
+
+	for _, spec := range []string{"order:abca", "order:abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789αβ"} {
+		if _, err := LoadScheme(spec); err == nil {
+			t.Errorf("LoadScheme(%q) succeeded, want an error", spec)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package day3
+
+import (
+	"math/bits"
+)
+
+// ItemSet is a set of item types with one bit per type. The bits are
+// handed out by a PriorityScheme, so a set fits in a single word and
+// intersecting rucksacks is a bitwise and. Bit 0 is unused.
+type ItemSet uint64
+
+// maxItemTypes is the most item types a scheme can have.
+const maxItemTypes = 63
+
+// Items returns the set of item types in a rucksack under the AoC scheme,
+// ignoring anything that is not an item type.
+func Items(rucksack string) ItemSet {
+	return AoC.Items(rucksack)
+}
+
+// Intersect returns the item types found in every group under the AoC
+// scheme. With no groups the set is empty.
+func Intersect(groups ...string) ItemSet {
+	return AoC.Intersect(groups...)
+}
+
+// Has reports whether the set holds the item type with bit b.
+func (s ItemSet) Has(b int) bool {
+	return b > 0 && b <= maxItemTypes && s&(1<<b) != 0
+}
+
+// Len returns the number of item types in the set.
+func (s ItemSet) Len() int {
+	return bits.OnesCount64(uint64(s))
+}
+
+// Bits returns the bits in the set in ascending order. Under the AoC
+// scheme they are the priorities.
+func (s ItemSet) Bits() []int {
+	bs := make([]int, 0, s.Len())
+	for s != 0 {
+		b := bits.TrailingZeros64(uint64(s))
+		bs = append(bs, b)
+		s &^= 1 << b
+	}
+	return bs
+}
+
+// Lowest returns the smallest bit in the set, which is the item type with
+// the lowest priority, or 0 if the set is empty.
+func (s ItemSet) Lowest() int {
+	if s == 0 {
+		return 0
+	}
+	return bits.TrailingZeros64(uint64(s))
+}
@@ -0,0 +1,191 @@
+package day3
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// PriorityScheme decides which characters are item types and what each is
+// worth. Item types get ItemSet bits in order of priority, so the lowest
+// bit of a set is its lowest priority item.
+type PriorityScheme struct {
+	bit      map[rune]int
+	ascii    [utf8.RuneSelf]uint8
+	items    []rune
+	priority []int
+}
+
+// AoC is the puzzle's scheme: a through z are 1 through 26 and A through Z
+// are 27 through 52.
+var AoC = mustOrdered("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+
+func mustOrdered(order string) *PriorityScheme {
+	s, err := OrderedScheme(order)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// OrderedScheme gives the characters of order the priorities 1, 2, 3 and
+// so on.
+func OrderedScheme(order string) (*PriorityScheme, error) {
+	priorities := map[rune]int{}
+	for i, ch := range []rune(order) {
+		if _, dup := priorities[ch]; dup {
+			return nil, fmt.Errorf("day3: scheme: %q appears twice in the ordering", ch)
+		}
+		priorities[ch] = i + 1
+	}
+	return newScheme(priorities)
+}
+
+// MapScheme uses the priorities in m, keyed by single characters.
+func MapScheme(m map[string]int) (*PriorityScheme, error) {
+	priorities := map[rune]int{}
+	for item, p := range m {
+		ch, size := utf8.DecodeRuneInString(item)
+		if size == 0 || size != len(item) {
+			return nil, fmt.Errorf("day3: scheme: item type %q must be a single character", item)
+		}
+		if p < 1 {
+			return nil, fmt.Errorf("day3: scheme: %q has priority %v, want at least 1", item, p)
+		}
+		priorities[ch] = p
+	}
+	return newScheme(priorities)
+}
+
+// ReadScheme reads a JSON object mapping item types to priorities, such as
+// {"a": 1, "ä": 2, "0": 3}.
+func ReadScheme(r io.Reader) (*PriorityScheme, error) {
+	var m map[string]int
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("day3: scheme: %w", err)
+	}
+	return MapScheme(m)
+}
+
+// LoadScheme returns the scheme named by spec: "aoc", "order:" followed by
+// an ordering string, or the path of a JSON scheme file.
+func LoadScheme(spec string) (*PriorityScheme, error) {
+	switch {
+	case spec == "aoc":
+		return AoC, nil
+	case strings.HasPrefix(spec, "order:"):
+		return OrderedScheme(strings.TrimPrefix(spec, "order:"))
+	}
+
+	f, err := os.Open(spec)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	s, err := ReadScheme(f)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", spec, err)
+	}
+	return s, nil
+}
+
+func newScheme(priorities map[rune]int) (*PriorityScheme, error) {
+	if len(priorities) == 0 {
+		return nil, fmt.Errorf("day3: scheme has no item types")
+	}
+	if len(priorities) > maxItemTypes {
+		return nil, fmt.Errorf("day3: scheme has %v item types, at most %v are supported", len(priorities), maxItemTypes)
+	}
+
+	items := make([]rune, 0, len(priorities))
+	for ch := range priorities {
+		items = append(items, ch)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if priorities[a] != priorities[b] {
+			return priorities[a] < priorities[b]
+		}
+		return a < b
+	})
+
+	s := &PriorityScheme{
+		bit:      map[rune]int{},
+		items:    append([]rune{0}, items...),
+		priority: []int{0},
+	}
+	for i, ch := range items {
+		s.bit[ch] = i + 1
+		if ch < utf8.RuneSelf {
+			s.ascii[ch] = uint8(i + 1)
+		}
+		s.priority = append(s.priority, priorities[ch])
+	}
+	return s, nil
+}
+
+// Valid reports whether ch is an item type.
+func (s *PriorityScheme) Valid(ch rune) bool {
+	_, ok := s.bit[ch]
+	return ok
+}
+
+// Priority returns the priority of the item type with bit b, or 0.
+func (s *PriorityScheme) Priority(b int) int {
+	if b < 1 || b >= len(s.priority) {
+		return 0
+	}
+	return s.priority[b]
+}
+
+// Item returns the item type with bit b.
+func (s *PriorityScheme) Item(b int) rune {
+	if b < 1 || b >= len(s.items) {
+		return utf8.RuneError
+	}
+	return s.items[b]
+}
+
+// Items returns the set of item types in a rucksack, ignoring anything
+// that is not an item type.
+func (s *PriorityScheme) Items(rucksack string) ItemSet {
+	var set ItemSet
+	for _, ch := range rucksack {
+		if ch < utf8.RuneSelf {
+			// ASCII that is not an item type sets the unused bit 0,
+			// cleared below.
+			set |= 1 << s.ascii[ch]
+		} else if b, ok := s.bit[ch]; ok {
+			set |= 1 << b
+		}
+	}
+	return set &^ 1
+}
+
+// Intersect returns the item types found in every group. With no groups
+// the set is empty.
+func (s *PriorityScheme) Intersect(groups ...string) ItemSet {
+	if len(groups) == 0 {
+		return 0
+	}
+
+	set := s.Items(groups[0])
+	for _, g := range groups[1:] {
+		set &= s.Items(g)
+	}
+	return set
+}
+
+// ItemString lists the item types in set in priority order.
+func (s *PriorityScheme) ItemString(set ItemSet) string {
+	var b strings.Builder
+	for _, bit := range set.Bits() {
+		b.WriteRune(s.Item(bit))
+	}
+	return b.String()
+}
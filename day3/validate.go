@@ -0,0 +1,181 @@
+package day3
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/asc521/aoc-2022/solver"
+)
+
+// Kinds of packing violation.
+const (
+	EmptyRucksack   = "empty-rucksack"
+	OddLength       = "odd-length"
+	InvalidItem     = "invalid-item"
+	NoSharedItem    = "no-shared-item"
+	SharedItems     = "several-shared-items"
+	NoBadge         = "no-badge"
+	SeveralBadges   = "several-badges"
+	IncompleteGroup = "incomplete-group"
+)
+
+// Violation is one problem with the inventory. Line is the rucksack's line,
+// or the first line of the group for group problems, and Items the item
+// types involved.
+type Violation struct {
+	Line    int    `json:"line"`
+	Col     int    `json:"col,omitempty"`
+	Kind    string `json:"kind"`
+	Items   string `json:"items,omitempty"`
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	if v.Col > 0 {
+		return fmt.Sprintf("line %v, col %v: %v: %v", v.Line, v.Col, v.Kind, v.Message)
+	}
+	return fmt.Sprintf("line %v: %v: %v", v.Line, v.Kind, v.Message)
+}
+
+// ValidationReport is everything wrong with an inventory.
+type ValidationReport struct {
+	Rucksacks  int         `json:"rucksacks"`
+	Groups     int         `json:"groups"`
+	Violations []Violation `json:"violations"`
+}
+
+// Validate checks every rucksack and every group of groupSize rucksacks
+// against scheme and reports all violations instead of stopping at the
+// first. The error is only for failing to read r.
+func Validate(r io.Reader, scheme *PriorityScheme, groupSize int) (ValidationReport, error) {
+	rep := ValidationReport{Violations: []Violation{}}
+	if groupSize < 2 {
+		return rep, fmt.Errorf("day3: group size must be at least 2, got %v", groupSize)
+	}
+
+	add := func(v Violation) {
+		rep.Violations = append(rep.Violations, v)
+	}
+
+	var group []string
+	groupLine := 0
+	lineNum := 0
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		lineNum++
+		rep.Rucksacks++
+
+		if len(group) == 0 {
+			groupLine = lineNum
+		}
+		group = append(group, line)
+
+		items := []rune(line)
+		switch {
+		case len(items) == 0:
+			add(Violation{Line: lineNum, Kind: EmptyRucksack, Message: "rucksack holds no items"})
+		case len(items)%2 != 0:
+			add(Violation{Line: lineNum, Kind: OddLength, Message: fmt.Sprintf("odd number of items (%v) cannot fill two compartments", len(items))})
+		}
+
+		for i, ch := range items {
+			if !scheme.Valid(ch) {
+				add(Violation{Line: lineNum, Col: i + 1, Kind: InvalidItem, Items: string(ch), Message: fmt.Sprintf("%q is not an item type", ch)})
+			}
+		}
+
+		if len(items) > 0 && len(items)%2 == 0 {
+			shared := scheme.Intersect(string(items[:len(items)/2]), string(items[len(items)/2:]))
+			switch shared.Len() {
+			case 0:
+				add(Violation{Line: lineNum, Kind: NoSharedItem, Message: "compartments share no item type"})
+			case 1:
+			default:
+				add(Violation{Line: lineNum, Kind: SharedItems, Items: scheme.ItemString(shared), Message: fmt.Sprintf("compartments share %v item types: %v", shared.Len(), scheme.ItemString(shared))})
+			}
+		}
+
+		if len(group) == groupSize {
+			rep.Groups++
+			badges := scheme.Intersect(group...)
+			switch badges.Len() {
+			case 0:
+				add(Violation{Line: groupLine, Kind: NoBadge, Message: fmt.Sprintf("group of %v has no common badge", groupSize)})
+			case 1:
+			default:
+				add(Violation{Line: groupLine, Kind: SeveralBadges, Items: scheme.ItemString(badges), Message: fmt.Sprintf("group of %v has %v badge candidates: %v", groupSize, badges.Len(), scheme.ItemString(badges))})
+			}
+			group = group[:0]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return rep, err
+	}
+
+	if len(group) > 0 {
+		add(Violation{Line: groupLine, Kind: IncompleteGroup, Message: fmt.Sprintf("last group has %v rucksacks, want %v", len(group), groupSize)})
+	}
+	return rep, nil
+}
+
+func init() {
+	solver.RegisterCommand(3, &validateCommand{})
+}
+
+// validateCommand prints a ValidationReport and fails if it has
+// violations.
+type validateCommand struct {
+	groupSize int
+	format    string
+	scheme    string
+}
+
+func (*validateCommand) Name() string { return "validate" }
+
+func (*validateCommand) Synopsis() string {
+	return "report every packing violation and exit non-zero if there are any"
+}
+
+func (c *validateCommand) SetFlags(fs *flag.FlagSet) {
+	fs.IntVar(&c.groupSize, "group-size", 3, "number of elves sharing a badge")
+	fs.StringVar(&c.format, "format", "text", "report format: text or json")
+	schemeFlag(fs, &c.scheme)
+}
+
+func (c *validateCommand) Run(in io.Reader, out io.Writer) error {
+	if c.format != "text" && c.format != "json" {
+		return fmt.Errorf("unknown format %q (want text or json)", c.format)
+	}
+
+	scheme, err := LoadScheme(c.scheme)
+	if err != nil {
+		return err
+	}
+
+	rep, err := Validate(in, scheme, c.groupSize)
+	if err != nil {
+		return err
+	}
+
+	if c.format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(rep); err != nil {
+			return err
+		}
+	} else {
+		for _, v := range rep.Violations {
+			fmt.Fprintln(out, v)
+		}
+		fmt.Fprintf(out, "%v rucksacks, %v groups, %v violations\n", rep.Rucksacks, rep.Groups, len(rep.Violations))
+	}
+
+	if len(rep.Violations) > 0 {
+		return fmt.Errorf("%v violations", len(rep.Violations))
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+package day4
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/asc521/aoc-2022/solver"
+)
+
+// Segment is a run of sections covered by the same number of elves.
+type Segment struct {
+	Sections Range
+	Elves    int
+}
+
+// Index answers coverage questions across every assignment in a file, not
+// just within pairs. It keeps the assignments sorted by their first section
+// as an implicit interval tree, and sweeps their endpoints once into
+// segments of constant coverage.
+type Index struct {
+	assignments []Assignment
+	maxHi       []int
+	segments    []Segment
+}
+
+// Assignments flattens pairs into their assignments in elf order.
+func Assignments(pairs []Pair) []Assignment {
+	as := make([]Assignment, 0, 2*len(pairs))
+	for _, p := range pairs {
+		as = append(as, p.One, p.Two)
+	}
+	return as
+}
+
+// NewIndex builds an index over as.
+func NewIndex(as []Assignment) *Index {
+	ix := &Index{assignments: append([]Assignment(nil), as...)}
+	sort.SliceStable(ix.assignments, func(i, j int) bool {
+		return ix.assignments[i].Sections.Lo < ix.assignments[j].Sections.Lo
+	})
+
+	ix.maxHi = make([]int, len(ix.assignments))
+	ix.buildTree(0, len(ix.assignments))
+	ix.sweep()
+	return ix
+}
+
+// buildTree records for the subtree rooted at the middle of [lo, hi) the
+// last section any assignment in it covers.
+func (ix *Index) buildTree(lo, hi int) int {
+	if lo >= hi {
+		return -1
+	}
+	mid := (lo + hi) / 2
+	m := ix.assignments[mid].Sections.Hi
+	m = max(m, ix.buildTree(lo, mid))
+	m = max(m, ix.buildTree(mid+1, hi))
+	ix.maxHi[mid] = m
+	return m
+}
+
+// sweep turns the start and end of every assignment into segments between
+// the first and last section assigned, including uncovered gaps.
+func (ix *Index) sweep() {
+	delta := map[int]int{}
+	for _, a := range ix.assignments {
+		delta[a.Sections.Lo]++
+		delta[a.Sections.Hi+1]--
+	}
+
+	points := make([]int, 0, len(delta))
+	for p := range delta {
+		points = append(points, p)
+	}
+	sort.Ints(points)
+
+	elves := 0
+	for i, p := range points[:max(len(points)-1, 0)] {
+		elves += delta[p]
+		ix.segments = append(ix.segments, Segment{Sections: Range{Lo: p, Hi: points[i+1] - 1}, Elves: elves})
+	}
+}
+
+// Len returns the number of assignments in the index.
+func (ix *Index) Len() int {
+	return len(ix.assignments)
+}
+
+// Span returns the sections from the first to the last one assigned, and
+// false if there are no assignments.
+func (ix *Index) Span() (Range, bool) {
+	if len(ix.segments) == 0 {
+		return Range{}, false
+	}
+	return Range{Lo: ix.segments[0].Sections.Lo, Hi: ix.segments[len(ix.segments)-1].Sections.Hi}, true
+}
+
+// Coverage returns the segments making up camp, counting sections outside
+// every assignment as covered by no elves.
+func (ix *Index) Coverage(camp Range) []Segment {
+	var segs []Segment
+	next := camp.Lo
+	for _, s := range ix.segments {
+		part, ok := s.Sections.Intersect(camp)
+		if !ok {
+			continue
+		}
+		if part.Lo > next {
+			segs = append(segs, Segment{Sections: Range{Lo: next, Hi: part.Lo - 1}})
+		}
+		segs = append(segs, Segment{Sections: part, Elves: s.Elves})
+		next = part.Hi + 1
+	}
+	if next <= camp.Hi {
+		segs = append(segs, Segment{Sections: Range{Lo: next, Hi: camp.Hi}})
+	}
+	return segs
+}
+
+// Where returns the sections of camp whose number of elves satisfies
+// match, with neighboring sections merged into ranges.
+func (ix *Index) Where(camp Range, match func(elves int) bool) []Range {
+	var rs []Range
+	for _, s := range ix.Coverage(camp) {
+		if !match(s.Elves) {
+			continue
+		}
+		if n := len(rs); n > 0 {
+			if u, ok := rs[n-1].Union(s.Sections); ok {
+				rs[n-1] = u
+				continue
+			}
+		}
+		rs = append(rs, s.Sections)
+	}
+	return rs
+}
+
+// MaxCoverage returns the most elves assigned to any one section and the
+// sections where that many are.
+func (ix *Index) MaxCoverage() (int, []Range) {
+	most := 0
+	for _, s := range ix.segments {
+		most = max(most, s.Elves)
+	}
+	span, ok := ix.Span()
+	if !ok {
+		return 0, nil
+	}
+	return most, ix.Where(span, func(elves int) bool { return elves == most })
+}
+
+// At returns the assignments covering section, in elf order.
+func (ix *Index) At(section int) []Assignment {
+	var found []Assignment
+	ix.stab(0, len(ix.assignments), section, &found)
+	sort.Slice(found, func(i, j int) bool { return found[i].Elf < found[j].Elf })
+	return found
+}
+
+func (ix *Index) stab(lo, hi, section int, found *[]Assignment) {
+	if lo >= hi {
+		return
+	}
+	mid := (lo + hi) / 2
+	if ix.maxHi[mid] < section {
+		return
+	}
+
+	ix.stab(lo, mid, section, found)
+	a := ix.assignments[mid]
+	if a.Sections.Lo > section {
+		return
+	}
+	if a.Sections.Hi >= section {
+		*found = append(*found, a)
+	}
+	ix.stab(mid+1, hi, section, found)
+}
+
+// sectionCount totals the sections in rs.
+func sectionCount(rs []Range) int {
+	n := 0
+	for _, r := range rs {
+		n += r.Len()
+	}
+	return n
+}
+
+func init() {
+	solver.RegisterCommand(4, &coverageCommand{})
+}
+
+// coverageCommand reports how the whole camp is covered, to spot work
+// duplicated across pairs.
+type coverageCommand struct {
+	k       int
+	section int
+	camp    string
+}
+
+func (*coverageCommand) Name() string { return "coverage" }
+
+func (*coverageCommand) Synopsis() string {
+	return "show which sections every elf together covers, and who covers a section"
+}
+
+func (c *coverageCommand) SetFlags(fs *flag.FlagSet) {
+	fs.IntVar(&c.k, "k", 1, "also list the sections covered by more than k elves")
+	fs.IntVar(&c.section, "section", -1, "list the elves covering this section instead")
+	fs.StringVar(&c.camp, "camp", "", "sections of the camp, like 1-99; the assigned span by default")
+}
+
+func (c *coverageCommand) Run(in io.Reader, out io.Writer) error {
+	pairs, err := ReadPairs(in)
+	if err != nil {
+		return err
+	}
+	ix := NewIndex(Assignments(pairs))
+
+	if c.section >= 0 {
+		as := ix.At(c.section)
+		fmt.Fprintf(out, "section %v: %v elves\n", c.section, len(as))
+		for _, a := range as {
+			fmt.Fprintf(out, "elf %v (line %v): %v\n", a.Elf, a.Line, a.Sections)
+		}
+		return nil
+	}
+
+	camp, ok := ix.Span()
+	if c.camp != "" {
+		if camp, err = ParseRange(c.camp); err != nil {
+			return err
+		}
+	} else if !ok {
+		return fmt.Errorf("day4: no assignments")
+	}
+
+	fmt.Fprintf(out, "%v elves, camp %v\n", ix.Len(), camp)
+	for _, q := range []struct {
+		label string
+		match func(int) bool
+	}{
+		{"nobody", func(n int) bool { return n == 0 }},
+		{"exactly one", func(n int) bool { return n == 1 }},
+		{fmt.Sprintf("more than %v", c.k), func(n int) bool { return n > c.k }},
+	} {
+		rs := ix.Where(camp, q.match)
+		writeRanges(out, q.label, rs)
+	}
+
+	most, rs := ix.MaxCoverage()
+	writeRanges(out, fmt.Sprintf("max coverage %v", most), rs)
+	return nil
+}
+
+func writeRanges(out io.Writer, label string, rs []Range) {
+	strs := make([]string, len(rs))
+	for i, r := range rs {
+		strs[i] = r.String()
+	}
+	fmt.Fprintf(out, "%v: %v sections", label, sectionCount(rs))
+	if len(rs) > 0 {
+		fmt.Fprintf(out, " (%v)", strings.Join(strs, ", "))
+	}
+	fmt.Fprintln(out)
+}
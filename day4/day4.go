@@ -0,0 +1,225 @@
+package day4
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/asc521/aoc-2022/logger"
+	"github.com/asc521/aoc-2022/parse"
+	"github.com/asc521/aoc-2022/solver"
+)
+
+/*
+--- Day 4: Camp Cleanup ---
+
+Space needs to be cleared before the last supplies can be unloaded from the ships, and so several Elves have been assigned the job of cleaning up sections of the camp. Every section has a unique ID number, and each Elf is assigned a range of section IDs.
+
+However, as some of the Elves compare their section assignments with each other, they've noticed that many of the assignments overlap. To try to quickly find overlaps and reduce duplicated effort, the Elves pair up and make a big list of the section assignments for each pair (your puzzle input).
+
+For example, consider the following list of section assignment pairs:
+
+2-4,6-8
+2-3,4-5
+5-7,7-9
+2-8,3-7
+6-6,4-6
+2-6,4-8
+
+For the first few pairs, this list means:
+
+    Within the first pair of Elves, the first Elf was assigned sections 2-4 (sections 2, 3, and 4), while the second Elf was assigned sections 6-8 (sections 6, 7, 8).
+    The Elves in the second pair were each assigned two sections.
+    The Elves in the third pair were each assigned three sections: one got sections 5, 6, and 7, while the other also got 7, plus 8 and 9.
+
+This example list uses single-digit section IDs to make it easier to draw; your actual list might contain larger numbers. Visually, these pairs of section assignments look like this:
+
+.234.....  2-4
+.....678.  6-8
+
+.23......  2-3
+...45....  4-5
+
+....567..  5-7
+......789  7-9
+
+.2345678.  2-8
+..34567..  3-7
+
+.....6...  6-6
+...456...  4-6
+
+.23456...  2-6
+...45678.  4-8
+
+Some of the pairs have noticed that one of their assignments fully contains the other. For example, 2-8 fully contains 3-7, and 6-6 is fully contained by 4-6. In pairs where one assignment fully contains the other, one Elf in the pair would be exclusively cleaning sections their partner will already be cleaning, so these seem like the most in need of reconsideration. In this example, there are 2 such pairs.
+
+In how many assignment pairs does one range fully contain the other?
+
+https://adventofcode.com/2022/day/4
+
+*/
+
+// atLine moves a parse error for part of a line to its place in the input.
+func atLine(err error, lineNum, offset int, line string) error {
+	var pe *parse.ParseError
+	if errors.As(err, &pe) {
+		pe.Line = lineNum
+		pe.Col += offset
+		pe.Text = line
+	}
+	return err
+}
+
+// counts reports whether a pair of assignments counts towards the answer:
+// in part 1 when one contains the other, in part 2 when they overlap.
+func counts(part int, one, two Range) bool {
+	if part == 1 {
+		return one.Contains(two) || two.Contains(one)
+	}
+	return one.Overlaps(two)
+}
+
+func init() {
+	solver.Register(4, Solver{})
+}
+
+// Solver answers day 4 from a list of section assignment pairs.
+type Solver struct{}
+
+func (Solver) PartOne(r io.Reader) (string, error) {
+	return countOverlaps(r, 1)
+}
+
+func (Solver) PartTwo(r io.Reader) (string, error) {
+	return countOverlaps(r, 2)
+}
+
+func countOverlaps(r io.Reader, part int) (string, error) {
+	pairs, err := ReadPairs(r)
+	if err != nil {
+		return "", err
+	}
+
+	fullyOverlappped := 0
+	for _, p := range pairs {
+		if counts(part, p.One.Sections, p.Two.Sections) {
+			logger.Infof("Assignments overlap (part %v): %v    %v", part, p.One.Text, p.Two.Text)
+			fullyOverlappped += 1
+		}
+	}
+
+	return strconv.Itoa(fullyOverlappped), nil
+}
+
+// Assignment is the range of sections given to one elf. Elves are numbered
+// from 1 in the order they appear, and Text is the assignment as written.
+type Assignment struct {
+	Elf      int
+	Line     int
+	Text     string
+	Sections Range
+}
+
+// Pair is two elves assigned together.
+type Pair struct {
+	One, Two Assignment
+}
+
+// ReadPairs reads every pair of assignments, either written as ranges like
+// 2-4,6-8 or drawn as two rows of sections separated from the next pair by
+// a blank line. A drawing may start with a single-line header numbering its
+// columns; without one each column is a section from 1 to 9.
+func ReadPairs(r io.Reader) ([]Pair, error) {
+	scanner := bufio.NewScanner(r)
+	var pairs []Pair
+	var assignmentOne string
+	var assignmentTwo string
+	lineNum := 0
+	elf := 0
+	rl, hasRuler := puzzleRuler, false
+	assign := func(line int, text string, sections Range) Assignment {
+		elf++
+		return Assignment{Elf: elf, Line: line, Text: text, Sections: sections}
+	}
+
+	// loneRow handles a line with no partner: the ruler, if it is the first
+	// line and numbers columns, and otherwise an error.
+	loneRow := func(line string, lineNum int) error {
+		if hasRuler || len(pairs) > 0 || strings.Trim(line, " 0123456789") != "" {
+			return &parse.ParseError{Day: 4, Line: lineNum, Col: 1, Text: line, Cause: errors.New("row has no partner, want a pair like 2-4,6-8 or two drawn rows")}
+		}
+		var err error
+		if rl, err = parseRuler(line); err != nil {
+			return atLine(err, lineNum, 0, line)
+		}
+		hasRuler = true
+		return nil
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		lineNum++
+		assignments := strings.Split(line, ",")
+		if len(assignments) == 2 {
+			elfOne, err := ParseRange(assignments[0])
+			if err != nil {
+				return nil, atLine(err, lineNum, 0, line)
+			}
+			elfTwo, err := ParseRange(assignments[1])
+			if err != nil {
+				return nil, atLine(err, lineNum, len(assignments[0])+1, line)
+			}
+
+			one := assign(lineNum, assignments[0], elfOne)
+			pairs = append(pairs, Pair{One: one, Two: assign(lineNum, assignments[1], elfTwo)})
+
+		} else if len(assignments) == 1 {
+			if line == "" {
+				if assignmentOne != "" && assignmentTwo == "" {
+					if err := loneRow(assignmentOne, lineNum-1); err != nil {
+						return nil, err
+					}
+				}
+				assignmentOne = ""
+				assignmentTwo = ""
+				continue
+			} else if assignmentOne != "" && assignmentTwo == "" {
+				assignmentTwo = line
+				elfOne, err := parseDrawnRange(assignmentOne, rl)
+				if err != nil {
+					return nil, atLine(err, lineNum-1, 0, assignmentOne)
+				}
+				elfTwo, err := parseDrawnRange(assignmentTwo, rl)
+				if err != nil {
+					return nil, atLine(err, lineNum, 0, assignmentTwo)
+				}
+
+				one := assign(lineNum-1, assignmentOne, elfOne)
+				pairs = append(pairs, Pair{One: one, Two: assign(lineNum, assignmentTwo, elfTwo)})
+
+			} else if assignmentOne == "" && assignmentTwo == "" {
+				assignmentOne = line
+			} else {
+				return nil, &parse.ParseError{Day: 4, Line: lineNum, Col: 1, Text: line, Cause: errors.New("more than two rows in a drawn pair")}
+			}
+
+		} else {
+			col := len(assignments[0]) + len(assignments[1]) + 2
+			return nil, &parse.ParseError{Day: 4, Line: lineNum, Col: col, Text: line, Cause: fmt.Errorf("want 2 assignments, got %v", len(assignments))}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if assignmentOne != "" && assignmentTwo == "" {
+		if err := loneRow(assignmentOne, lineNum); err != nil {
+			return nil, err
+		}
+	}
+	return pairs, nil
+}
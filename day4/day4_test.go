@@ -0,0 +1,261 @@
+package day4
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/asc521/aoc-2022/parse"
+	"github.com/asc521/aoc-2022/solver/solvertest"
+)
+
+func TestSolver(t *testing.T) {
+	solvertest.Run(t, Solver{}, []solvertest.Case{
+		{Name: "example part 1", File: "section_assignments.txt", Part: 1, Want: "2"},
+		{Name: "example part 2", File: "section_assignments.txt", Part: 2, Want: "4"},
+		{Name: "visual example part 1", File: "large_section_assignments.txt", Part: 1, Want: "2"},
+		{Name: "visual example part 2", File: "large_section_assignments.txt", Part: 2, Want: "4"},
+	})
+}
+
+func TestRange(t *testing.T) {
+	tests := []struct {
+		a, b      Range
+		contains  bool
+		overlaps  bool
+		intersect string
+		union     string
+	}{
+		{Range{2, 8}, Range{3, 7}, true, true, "3-7", "2-8"},
+		{Range{2, 4}, Range{6, 8}, false, false, "", ""},
+		{Range{2, 4}, Range{5, 8}, false, false, "", "2-8"},
+		{Range{5, 7}, Range{7, 9}, false, true, "7-7", "5-9"},
+		{Range{6, 6}, Range{4, 6}, false, true, "6-6", "4-6"},
+		{Range{1, 1000000000}, Range{999999999, 1000000000}, true, true, "999999999-1000000000", "1-1000000000"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.a.Contains(tt.b); got != tt.contains {
+			t.Errorf("%v.Contains(%v) = %v, want %v", tt.a, tt.b, got, tt.contains)
+		}
+		if got := tt.a.Overlaps(tt.b); got != tt.overlaps || tt.b.Overlaps(tt.a) != tt.overlaps {
+			t.Errorf("%v.Overlaps(%v) = %v, want %v", tt.a, tt.b, got, tt.overlaps)
+		}
+
+		got := ""
+		if r, ok := tt.a.Intersect(tt.b); ok {
+			got = r.String()
+		}
+		if got != tt.intersect {
+			t.Errorf("%v.Intersect(%v) = %q, want %q", tt.a, tt.b, got, tt.intersect)
+		}
+
+		got = ""
+		if r, ok := tt.a.Union(tt.b); ok {
+			got = r.String()
+		}
+		if got != tt.union {
+			t.Errorf("%v.Union(%v) = %q, want %q", tt.a, tt.b, got, tt.union)
+		}
+	}
+
+	if n := (Range{1, 1000000000}).Len(); n != 1000000000 {
+		t.Errorf("Len = %v, want 1000000000", n)
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	got, err := Solver{}.PartOne(strings.NewReader("1-1000000000,2-999999999\n"))
+	if err != nil || got != "1" {
+		t.Errorf("huge range: got %v, %v, want 1", got, err)
+	}
+
+	tests := []struct {
+		input string
+		col   int
+	}{
+		{"4-2,1-3", 3},
+		{"-1-2,1-3", 1},
+		{"1-2,3--1", 7},
+		{"1-2,3", 5},
+		{"..3.5..\n.1.....\n", 5},
+		{"..35...\n.1.....\n", 4},
+	}
+
+	for _, tt := range tests {
+		_, err := Solver{}.PartOne(strings.NewReader(tt.input))
+		var pe *parse.ParseError
+		if !errors.As(err, &pe) || pe.Col != tt.col {
+			t.Errorf("%q: got %v, want a parse error at column %v", tt.input, err, tt.col)
+		}
+	}
+}
+
+func TestIndex(t *testing.T) {
+	pairs, err := ReadPairs(strings.NewReader("2-4,6-8\n2-3,4-5\n5-7,7-9\n12-12,1-1000000000\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	as := Assignments(pairs)
+	ix := NewIndex(as)
+
+	camp := Range{0, 13}
+	for _, tt := range []struct {
+		name  string
+		match func(int) bool
+		want  string
+	}{
+		{"nobody", func(n int) bool { return n == 0 }, "[0-0]"},
+		{"one", func(n int) bool { return n == 1 }, "[1-1 10-11 13-13]"},
+		{"more than 2", func(n int) bool { return n > 2 }, "[2-8]"},
+	} {
+		if got := fmt.Sprint(ix.Where(camp, tt.match)); got != tt.want {
+			t.Errorf("%v: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	most, where := ix.MaxCoverage()
+	if most != 4 || fmt.Sprint(where) != "[7-7]" {
+		t.Errorf("MaxCoverage() = %v, %v, want 4, [7-7]", most, where)
+	}
+
+	for section := 0; section <= 13; section++ {
+		want := []int{}
+		for _, a := range as {
+			if a.Sections.Contains(Range{section, section}) {
+				want = append(want, a.Elf)
+			}
+		}
+		got := []int{}
+		for _, a := range ix.At(section) {
+			got = append(got, a.Elf)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("At(%v) = %v, want %v", section, got, want)
+		}
+	}
+}
+
+func TestVisualRoundTrip(t *testing.T) {
+	for _, name := range []string{"section_assignments.txt", "large_section_assignments.txt"} {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pairs, err := ReadPairs(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var drawn, list bytes.Buffer
+		if err := DrawPairs(&drawn, pairs, false); err != nil {
+			t.Fatal(err)
+		}
+		back, err := ReadPairs(bytes.NewReader(drawn.Bytes()))
+		if err != nil {
+			t.Fatalf("%v: reading the drawing back: %v", name, err)
+		}
+		if err := WriteList(&list, back); err != nil {
+			t.Fatal(err)
+		}
+
+		want := list.String()
+		if name == "large_section_assignments.txt" {
+			want = drawn.String()
+		}
+		if strings.TrimRight(string(data), "\n") != strings.TrimRight(want, "\n") {
+			t.Errorf("%v did not round-trip, got\n%v", name, want)
+		}
+	}
+}
+
+func TestVisualRuler(t *testing.T) {
+	pairs, err := ReadPairs(strings.NewReader("8-12,10-10\n98-99,99-100\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	if err := DrawPairs(&b, pairs[:1], true); err != nil {
+		t.Fatal(err)
+	}
+	want := `  1  2  3  4  5  6  7  8  9 10 11 12
+
+  .  .  .  .  .  .  .  8  9 10 11 12
+  .  .  .  .  .  .  .  .  . 10  .  .
+                            ^^
+`
+	if b.String() != want {
+		t.Errorf("DrawPairs with highlight:\n%v\nwant\n%v", b.String(), want)
+	}
+
+	b.Reset()
+	if err := DrawPairs(&b, pairs, false); err != nil {
+		t.Fatal(err)
+	}
+	back, err := ReadPairs(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var list strings.Builder
+	WriteList(&list, back)
+	if list.String() != "8-12,10-10\n98-99,99-100\n" {
+		t.Errorf("ruled drawing read back as\n%v", list.String())
+	}
+
+	for _, tt := range []struct {
+		input     string
+		line, col int
+	}{
+		{"  9 10 12\n\n  9 10  .\n  .  . 12\n", 1, 8},
+		{"  9 10 11\n\n  9 11  .\n  .  . 11\n", 3, 5},
+		{"  9 10 11\n\n  9 10  .  .\n  .  . 11 12\n", 4, 11},
+		{".234.....\n\n.....678.\n.23......\n", 1, 1},
+		{" 9 10 11\n\n 9 10  .\n .  . 11\n", 1, 2},
+		{"..3......\n.23......\n\n.......8.\n", 4, 1},
+		{"2-4\n", 1, 1},
+		{"garbage\n", 1, 1},
+		{"move 1 from 2 to 1\n", 1, 1},
+		{"2-4,6-8\n3-5\n\n", 2, 1},
+	} {
+		_, err := ReadPairs(strings.NewReader(tt.input))
+		var pe *parse.ParseError
+		if !errors.As(err, &pe) || pe.Line != tt.line || pe.Col != tt.col {
+			t.Errorf("%q: got %v, want a parse error at %v:%v", tt.input, err, tt.line, tt.col)
+		}
+	}
+}
+
+func TestReassign(t *testing.T) {
+	pairs, err := ReadPairs(strings.NewReader("2-4,6-8\n2-8,3-7\n6-6,4-6\n3-9,3-4\n2-7,2-7\n5-5,5-5\n1-9,6-7\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fixed, changes := Reassign(pairs)
+	var list strings.Builder
+	if err := WriteList(&list, fixed); err != nil {
+		t.Fatal(err)
+	}
+	want := "2-4,6-8\n8-8,2-7\n6-6,4-5\n5-9,3-4\n2-4,5-7\n5-5,5-5\n1-5,6-9\n"
+	if list.String() != want {
+		t.Errorf("corrected assignments:\n%v\nwant\n%v", list.String(), want)
+	}
+
+	saved := []int{}
+	for _, r := range changes {
+		saved = append(saved, r.Saved)
+		one, two := r.Fixed.One.Sections, r.Fixed.Two.Sections
+		before, _ := r.Pair.One.Sections.Union(r.Pair.Two.Sections)
+		if after, ok := one.Union(two); one.Overlaps(two) || !ok || after != before {
+			t.Errorf("%v,%v: fix %v,%v does not cover %v without overlap", r.Pair.One.Sections, r.Pair.Two.Sections, one, two, before)
+		}
+	}
+	if !reflect.DeepEqual(saved, []int{5, 1, 2, 6, 2}) {
+		t.Errorf("saved %v, want [5 1 2 6 2]", saved)
+	}
+}
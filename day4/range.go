@@ -0,0 +1,101 @@
+package day4
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/asc521/aoc-2022/parse"
+)
+
+// Range is the inclusive span of section IDs from Lo to Hi assigned to one
+// elf. Nothing is expanded, so the size of a range costs nothing.
+type Range struct {
+	Lo, Hi int
+}
+
+func (r Range) String() string {
+	return fmt.Sprintf("%v-%v", r.Lo, r.Hi)
+}
+
+// Len returns the number of sections in r.
+func (r Range) Len() int {
+	return r.Hi - r.Lo + 1
+}
+
+// Contains reports whether every section of o is also in r.
+func (r Range) Contains(o Range) bool {
+	return r.Lo <= o.Lo && o.Hi <= r.Hi
+}
+
+// Overlaps reports whether r and o share at least one section.
+func (r Range) Overlaps(o Range) bool {
+	return r.Lo <= o.Hi && o.Lo <= r.Hi
+}
+
+// Intersect returns the sections in both r and o, and false if there are
+// none.
+func (r Range) Intersect(o Range) (Range, bool) {
+	if !r.Overlaps(o) {
+		return Range{}, false
+	}
+	return Range{Lo: max(r.Lo, o.Lo), Hi: min(r.Hi, o.Hi)}, true
+}
+
+// Union returns the sections in either r or o, and false if they are
+// neither overlapping nor adjacent, so that no single range covers exactly
+// both.
+func (r Range) Union(o Range) (Range, bool) {
+	if r.Lo > o.Hi+1 || o.Lo > r.Hi+1 {
+		return Range{}, false
+	}
+	return Range{Lo: min(r.Lo, o.Lo), Hi: max(r.Hi, o.Hi)}, true
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// ParseRange reads an assignment like 2-4. Negative section IDs and ranges
+// that end before they start are errors.
+func ParseRange(s string) (Range, error) {
+	if strings.HasPrefix(s, "-") {
+		return Range{}, &parse.ParseError{Day: 4, Col: 1, Text: s, Cause: errors.New("section IDs cannot be negative")}
+	}
+
+	sep := strings.Index(s, "-")
+	if sep < 0 {
+		return Range{}, &parse.ParseError{Day: 4, Col: 1, Text: s, Cause: fmt.Errorf("want a range like 2-4, got %q", s)}
+	}
+
+	lo, err := strconv.Atoi(s[:sep])
+	if err != nil {
+		return Range{}, &parse.ParseError{Day: 4, Col: 1, Text: s, Cause: err}
+	}
+
+	hiCol := sep + 2
+	if strings.HasPrefix(s[sep+1:], "-") {
+		return Range{}, &parse.ParseError{Day: 4, Col: hiCol, Text: s, Cause: errors.New("section IDs cannot be negative")}
+	}
+	hi, err := strconv.Atoi(s[sep+1:])
+	if err != nil {
+		return Range{}, &parse.ParseError{Day: 4, Col: hiCol, Text: s, Cause: err}
+	}
+
+	if hi < lo {
+		return Range{}, &parse.ParseError{Day: 4, Col: hiCol, Text: s, Cause: fmt.Errorf("range ends at %v before it starts at %v", hi, lo)}
+	}
+
+	return Range{Lo: lo, Hi: hi}, nil
+}
@@ -0,0 +1,117 @@
+package day4
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/asc521/aoc-2022/solver"
+)
+
+// Reassignment is the fix proposed for a pair where one elf's range
+// contains the other's. Fixed is the pair with the union of its sections
+// split between the two elves without overlap, and Saved the number of
+// sections no longer cleaned twice.
+type Reassignment struct {
+	Pair  Pair
+	Fixed Pair
+	Saved int
+}
+
+// Reassign proposes a fix for every fully contained pair and returns the
+// pairs with those fixes applied alongside the fixes themselves. A pair
+// sharing a single section cannot be split and is left as it is.
+func Reassign(pairs []Pair) ([]Pair, []Reassignment) {
+	fixed := make([]Pair, len(pairs))
+	var changes []Reassignment
+	for i, p := range pairs {
+		fixed[i] = p
+		one, two := p.One.Sections, p.Two.Sections
+		if !counts(1, one, two) {
+			continue
+		}
+
+		var ok bool
+		if one.Contains(two) {
+			one, two, ok = splitContained(one, two)
+		} else {
+			two, one, ok = splitContained(two, one)
+		}
+		if !ok {
+			continue
+		}
+
+		fixed[i].One = reassigned(p.One, one)
+		fixed[i].Two = reassigned(p.Two, two)
+		saved, _ := p.One.Sections.Intersect(p.Two.Sections)
+		changes = append(changes, Reassignment{Pair: p, Fixed: fixed[i], Saved: saved.Len()})
+	}
+	return fixed, changes
+}
+
+func reassigned(a Assignment, sections Range) Assignment {
+	a.Sections = sections
+	a.Text = sections.String()
+	return a
+}
+
+// splitContained divides outer, which contains inner, into two ranges that
+// cover it without overlap. The inner elf keeps its range and takes over the
+// shorter stretch beside it, so as little work as possible changes hands.
+// Identical ranges are halved.
+func splitContained(outer, inner Range) (Range, Range, bool) {
+	if outer.Len() < 2 {
+		return outer, inner, false
+	}
+
+	if outer == inner {
+		mid := outer.Lo + outer.Len()/2
+		return Range{Lo: outer.Lo, Hi: mid - 1}, Range{Lo: mid, Hi: outer.Hi}, true
+	}
+
+	left, right := inner.Lo-outer.Lo, outer.Hi-inner.Hi
+	if left <= right {
+		return Range{Lo: inner.Hi + 1, Hi: outer.Hi}, Range{Lo: outer.Lo, Hi: inner.Hi}, true
+	}
+	return Range{Lo: outer.Lo, Hi: inner.Lo - 1}, Range{Lo: inner.Lo, Hi: outer.Hi}, true
+}
+
+func init() {
+	solver.RegisterCommand(4, &reassignCommand{})
+}
+
+// reassignCommand fixes fully contained pairs.
+type reassignCommand struct {
+	report bool
+}
+
+func (*reassignCommand) Name() string { return "reassign" }
+
+func (*reassignCommand) Synopsis() string {
+	return "split fully contained pairs so no section is cleaned twice"
+}
+
+func (c *reassignCommand) SetFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&c.report, "report", false, "list each change and the sections it saves instead of the corrected assignments")
+}
+
+func (c *reassignCommand) Run(in io.Reader, out io.Writer) error {
+	pairs, err := ReadPairs(in)
+	if err != nil {
+		return err
+	}
+
+	fixed, changes := Reassign(pairs)
+	if !c.report {
+		return WriteList(out, fixed)
+	}
+
+	total := 0
+	for _, r := range changes {
+		fmt.Fprintf(out, "line %v: %v,%v -> %v,%v saves %v sections\n", r.Pair.One.Line,
+			r.Pair.One.Sections, r.Pair.Two.Sections, r.Fixed.One.Sections, r.Fixed.Two.Sections, r.Saved)
+		total += r.Saved
+	}
+	fmt.Fprintf(out, "%v pairs reassigned, %v sections saved\n", len(changes), total)
+	return nil
+}
@@ -0,0 +1,219 @@
+package day4
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/asc521/aoc-2022/parse"
+	"github.com/asc521/aoc-2022/solver"
+)
+
+// ruler maps the columns of a drawing to sections. Cell k is stride
+// characters wide, starts at column k*stride and holds section first+k,
+// right-aligned.
+type ruler struct {
+	first, stride, cells int
+}
+
+// puzzleRuler is used for drawings without a header: one character per
+// section from 1 to 9, as in the puzzle text.
+var puzzleRuler = ruler{first: 1, stride: 1, cells: 9}
+
+var rulerNumberRX = regexp.MustCompile(`[0-9]+`)
+
+// parseRuler reads a header numbering the columns of a drawing, such as
+// " 8  9 10 11", with consecutive sections right-aligned in cells of equal
+// width.
+func parseRuler(line string) (ruler, error) {
+	if i := strings.IndexFunc(line, func(r rune) bool { return r != ' ' && (r < '0' || r > '9') }); i >= 0 {
+		return ruler{}, &parse.ParseError{Day: 4, Col: i + 1, Text: line, Cause: errors.New("ruler may only hold section numbers and spaces")}
+	}
+
+	nums := rulerNumberRX.FindAllStringIndex(line, -1)
+	if len(nums) == 0 {
+		return ruler{}, &parse.ParseError{Day: 4, Col: 1, Text: line, Cause: errors.New("ruler numbers no sections")}
+	}
+
+	rl := ruler{stride: nums[0][1], cells: len(nums)}
+	if len(nums) > 1 {
+		rl.stride = nums[1][1] - nums[0][1]
+	}
+	for k, m := range nums {
+		n, err := strconv.Atoi(line[m[0]:m[1]])
+		if err != nil {
+			return ruler{}, &parse.ParseError{Day: 4, Col: m[0] + 1, Text: line, Cause: err}
+		}
+		if k == 0 {
+			rl.first = n
+		} else if n != rl.first+k {
+			return ruler{}, &parse.ParseError{Day: 4, Col: m[0] + 1, Text: line, Cause: fmt.Errorf("section %v does not follow %v", n, rl.first+k-1)}
+		}
+		if m[1] != (k+1)*rl.stride || m[0] < k*rl.stride {
+			return ruler{}, &parse.ParseError{Day: 4, Col: m[0] + 1, Text: line, Cause: fmt.Errorf("section %v is not right-aligned in a %v-wide column", n, rl.stride)}
+		}
+	}
+	return rl, nil
+}
+
+// parseDrawnRange reads one row of a drawn pair, where the sections an elf
+// covers are written as their numbers in the ruler's columns and the rest
+// as dots, such as .234.....
+func parseDrawnRange(r string, rl ruler) (Range, error) {
+	var rng Range
+	found := false
+	for k, cell := range parse.Columns(r, rl.stride) {
+		mark := strings.TrimLeft(cell, " ")
+		col := k*rl.stride + len(cell) - len(mark) + 1
+		if strings.Trim(mark, ".") == "" {
+			continue
+		}
+
+		i, err := strconv.Atoi(mark)
+		if err != nil {
+			return Range{}, &parse.ParseError{Day: 4, Col: col, Text: r, Cause: err}
+		}
+		if k >= rl.cells {
+			return Range{}, &parse.ParseError{Day: 4, Col: col, Text: r, Cause: fmt.Errorf("section %v is past the last column of the ruler", i)}
+		}
+		if i != rl.first+k {
+			return Range{}, &parse.ParseError{Day: 4, Col: col, Text: r, Cause: fmt.Errorf("section %v is drawn in the column for %v", i, rl.first+k)}
+		}
+		if found && i != rng.Hi+1 {
+			return Range{}, &parse.ParseError{Day: 4, Col: col, Text: r, Cause: fmt.Errorf("section %v does not follow %v", i, rng.Hi)}
+		}
+
+		if !found {
+			rng.Lo = i
+		}
+		rng.Hi = i
+		found = true
+	}
+
+	if !found {
+		return Range{}, &parse.ParseError{Day: 4, Col: 1, Text: r, Cause: errors.New("row covers no sections")}
+	}
+	return rng, nil
+}
+
+// drawingRuler picks the ruler for drawing pairs: the puzzle's when every
+// section is from 1 to 9, otherwise a header from the first section drawn,
+// or 1, to the last.
+func drawingRuler(pairs []Pair) (ruler, bool) {
+	lo, hi := 1, 1
+	for _, p := range pairs {
+		for _, a := range []Assignment{p.One, p.Two} {
+			lo = min(lo, a.Sections.Lo)
+			hi = max(hi, a.Sections.Hi)
+		}
+	}
+
+	if lo == 1 && hi <= 9 {
+		return ruler{first: 1, stride: 1, cells: hi}, false
+	}
+
+	return ruler{first: lo, stride: len(strconv.Itoa(hi)) + 1, cells: hi - lo + 1}, true
+}
+
+// cell right-aligns s in a column of the ruler.
+func (rl ruler) cell(s string) string {
+	return strings.Repeat(" ", rl.stride-len(s)) + s
+}
+
+// DrawPairs writes pairs in the puzzle's dotted picture, each pair as two
+// rows separated from the next by a blank line. Sections beyond 9 get a
+// header ruler numbering the columns. With highlight, a row of carets under
+// each overlapping pair marks the sections both elves cover; such output is
+// for reading and does not parse back.
+func DrawPairs(w io.Writer, pairs []Pair, highlight bool) error {
+	bw := bufio.NewWriter(w)
+	rl, header := drawingRuler(pairs)
+	if header {
+		var b strings.Builder
+		for k := 0; k < rl.cells; k++ {
+			b.WriteString(rl.cell(strconv.Itoa(rl.first + k)))
+		}
+		fmt.Fprintf(bw, "%v\n\n", b.String())
+	}
+
+	for i, p := range pairs {
+		if i > 0 {
+			fmt.Fprintln(bw)
+		}
+		fmt.Fprintln(bw, rl.row(p.One.Sections, "."))
+		fmt.Fprintln(bw, rl.row(p.Two.Sections, "."))
+		if both, ok := p.One.Sections.Intersect(p.Two.Sections); highlight && ok {
+			fmt.Fprintln(bw, strings.TrimRight(rl.row(both, " "), " "))
+		}
+	}
+	return bw.Flush()
+}
+
+// row draws the sections of rng in the ruler's columns and blank for every
+// other column. A blank of " " marks rng with carets instead of numbers.
+func (rl ruler) row(rng Range, blank string) string {
+	var b strings.Builder
+	for k := 0; k < rl.cells; k++ {
+		section := rl.first + k
+		mark := strconv.Itoa(section)
+		switch {
+		case section < rng.Lo || section > rng.Hi:
+			mark = blank
+		case blank == " ":
+			mark = strings.Repeat("^", len(mark))
+		}
+		b.WriteString(rl.cell(mark))
+	}
+	return b.String()
+}
+
+// WriteList writes pairs as ranges, one pair per line like 2-4,6-8.
+func WriteList(w io.Writer, pairs []Pair) error {
+	bw := bufio.NewWriter(w)
+	for _, p := range pairs {
+		fmt.Fprintf(bw, "%v,%v\n", p.One.Sections, p.Two.Sections)
+	}
+	return bw.Flush()
+}
+
+func init() {
+	solver.RegisterCommand(4, &convertCommand{})
+}
+
+// convertCommand rewrites assignments between the list and drawn formats.
+type convertCommand struct {
+	to        string
+	highlight bool
+}
+
+func (*convertCommand) Name() string { return "convert" }
+
+func (*convertCommand) Synopsis() string {
+	return "rewrite the assignments as a list of ranges or as the dotted picture"
+}
+
+func (c *convertCommand) SetFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.to, "to", "visual", "output format: visual or list")
+	fs.BoolVar(&c.highlight, "highlight", false, "mark overlapping sections under each drawn pair")
+}
+
+func (c *convertCommand) Run(in io.Reader, out io.Writer) error {
+	pairs, err := ReadPairs(in)
+	if err != nil {
+		return err
+	}
+
+	switch c.to {
+	case "visual":
+		return DrawPairs(out, pairs, c.highlight)
+	case "list":
+		return WriteList(out, pairs)
+	default:
+		return fmt.Errorf("unknown format %q, want visual or list", c.to)
+	}
+}
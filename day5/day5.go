@@ -0,0 +1,357 @@
+package day5
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/asc521/aoc-2022/parse"
+	"github.com/asc521/aoc-2022/solver"
+)
+
+/*
+--- Day 5: Supply Stacks ---
+
+The expedition can depart as soon as the final supplies have been unloaded from the ships. Supplies are stored in stacks of marked crates, but because the needed supplies are buried under many other crates, the crates need to be rearranged.
+
+The ship has a giant cargo crane capable of moving crates between stacks. To ensure none of the crates get crushed or fall over, the crane operator will rearrange them in a series of carefully-planned steps. After the crates are rearranged, the desired crates will be at the top of each stack.
+
+The Elves don't want to interrupt the crane operator during this delicate procedure, but they forgot to ask her which crate will end up where, and they want to be ready to unload them as soon as possible so they can embark.
+
+They do, however, have a drawing of the starting stacks of crates and the rearrangement procedure (your puzzle input). For example:
+
+    [D]
+[N] [C]
+[Z] [M] [P]
+ 1   2   3
+
+move 1 from 2 to 1
+move 3 from 1 to 3
+move 2 from 2 to 1
+move 1 from 1 to 2
+
+In this example, there are three stacks of crates. Stack 1 contains two crates: crate Z is on the bottom, and crate N is on top. Stack 2 contains three crates; from bottom to top, they are crates M, C, and D. Finally, stack 3 contains a single crate, P.
+
+Then, the rearrangement procedure is given. In each step of the procedure, a quantity of crates is moved from one stack to a different stack. In the first step of the above rearrangement procedure, one crate is moved from stack 2 to stack 1, resulting in this configuration:
+
+[D]
+[N] [C]
+[Z] [M] [P]
+ 1   2   3
+
+In the second step, three crates are moved from stack 1 to stack 3. Crates are moved one at a time, so the first crate to be moved (D) ends up below the second and third crates:
+
+        [Z]
+        [N]
+    [C] [D]
+    [M] [P]
+ 1   2   3
+
+Then, both crates are moved from stack 2 to stack 1. Again, because crates are moved one at a time, crate C ends up below crate M:
+
+        [Z]
+        [N]
+[M]     [D]
+[C]     [P]
+ 1   2   3
+
+Finally, one crate is moved from stack 1 to stack 2:
+
+        [Z]
+        [N]
+        [D]
+[C] [M] [P]
+ 1   2   3
+
+The Elves just need to know which crate will end up on top of each stack; in this example, the top crates are C in stack 1, M in stack 2, and Z in stack 3, so you should combine these together and give the Elves the message CMZ.
+
+After the rearrangement procedure completes, what crate ends up on top of each stack?
+
+https://adventofcode.com/2022/day/5
+*/
+
+var (
+	ProcedureRX = regexp.MustCompile("^move (?P<quantity>[0-9]+) from (?P<origStack>[0-9]+) to (?P<destStack>[0-9]+)$")
+)
+
+type stack struct {
+	crates []string
+	count  int
+}
+
+func NewStack() *stack {
+	return &stack{}
+}
+
+func (s *stack) top() string {
+	if len(s.crates) == 0 {
+		return ""
+	}
+	return s.crates[0]
+}
+
+func (s *stack) push(crate string) {
+	s.crates = append(s.crates, crate)
+	s.count++
+}
+
+func (s *stack) prepend(crate []string) {
+	s.crates = append(crate, s.crates...)
+	s.count = s.count + len(crate)
+}
+
+func (s *stack) pop() string {
+	if s.count == 0 {
+		return ""
+	}
+	c := s.crates[0]
+	s.crates = s.crates[1:s.count]
+	s.count--
+	return c
+}
+
+type warehouse struct {
+	stacks []*stack
+}
+
+func NewWarehouse() *warehouse {
+	return &warehouse{stacks: []*stack{}}
+}
+
+func (w *warehouse) String() string {
+	var str string
+	for i, s := range w.stacks {
+		str += "Stack " + strconv.Itoa(i+1) + ": "
+		for _, c := range s.crates {
+			str += c + " "
+		}
+
+		str += "\n"
+	}
+
+	return str
+}
+
+func (w *warehouse) move(origStackLoc, destStackLoc, quantity int) {
+
+	origStack := w.stacks[origStackLoc-1]
+	destStack := w.stacks[destStackLoc-1]
+
+	for i := 1; i <= quantity; i++ {
+		c := origStack.pop()
+		destStack.prepend([]string{c})
+	}
+
+}
+
+func (w *warehouse) moveMultiple(origStackLoc, destStackLoc, quantity int) {
+	origStack := w.stacks[origStackLoc-1]
+	destStack := w.stacks[destStackLoc-1]
+
+	crates := []string{}
+	for i := 1; i <= quantity; i++ {
+		crates = append(crates, origStack.pop())
+	}
+	destStack.prepend(crates)
+
+}
+
+func (w *warehouse) topMarks() string {
+
+	var marks string
+	for _, s := range w.stacks {
+		marks += s.top()
+	}
+	return marks
+}
+
+func (w *warehouse) pushToStack(stack int, crate string) {
+
+	if stack > len(w.stacks) {
+		w.stacks = append(w.stacks, NewStack())
+	}
+
+	if crate != "" {
+		w.stacks[stack-1].push(crate)
+	}
+
+}
+
+type Procedure struct {
+	quantity  int
+	origStack int
+	destStack int
+
+	// line and text locate the step in the input for errors.
+	line int
+	text string
+}
+
+// procedureRecord is one line of the rearrangement procedure as decoded
+// from ProcedureRX.
+type procedureRecord struct {
+	Quantity  int `re:"quantity"`
+	OrigStack int `re:"origStack"`
+	DestStack int `re:"destStack"`
+}
+
+var procedure = parse.NewRecord(ProcedureRX)
+
+func parseInput(input io.Reader) (*warehouse, []Procedure, error) {
+	groups, err := parse.Groups(input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(groups) == 0 || len(groups) > 2 {
+		return nil, nil, &parse.ParseError{Day: 5, Cause: fmt.Errorf("want a drawing and a procedure separated by a blank line, got %v sections", len(groups))}
+	}
+
+	w, err := parseDrawing(groups[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p := []Procedure{}
+	if len(groups) == 1 {
+		return w, p, nil
+	}
+
+	steps := groups[1]
+	for i, line := range steps.Lines {
+		var rec procedureRecord
+		if err := procedure.Decode(line, &rec); err != nil {
+			var pe *parse.ParseError
+			if errors.As(err, &pe) {
+				pe.Day, pe.Line = 5, steps.Line+i
+				if pe.Col == 1 {
+					pe.Cause = errors.New(`want a step like "move 1 from 2 to 1"`)
+				}
+			}
+			return nil, nil, err
+		}
+
+		for _, ref := range []struct {
+			group string
+			stack int
+		}{{"origStack", rec.OrigStack}, {"destStack", rec.DestStack}} {
+			if stack := ref.stack; stack < 1 || stack > len(w.stacks) {
+				m := ProcedureRX.FindStringSubmatchIndex(line)
+				col := m[2*ProcedureRX.SubexpIndex(ref.group)] + 1
+				return nil, nil, &parse.ParseError{Day: 5, Line: steps.Line + i, Col: col, Text: line, Cause: fmt.Errorf("stack %v does not exist, there are %v stacks", stack, len(w.stacks))}
+			}
+		}
+
+		p = append(p, Procedure{
+			quantity:  rec.Quantity,
+			origStack: rec.OrigStack,
+			destStack: rec.DestStack,
+			line:      steps.Line + i,
+			text:      line,
+		})
+	}
+
+	return w, p, nil
+}
+
+// parseDrawing reads the stacks of crates. The last line of the drawing
+// numbers the stacks; each stack is a four character wide column above it.
+func parseDrawing(drawing parse.Group) (*warehouse, error) {
+	w := NewWarehouse()
+
+	last := len(drawing.Lines) - 1
+	ruler := drawing.Lines[last]
+	if strings.Contains(ruler, "[") || len(parse.Ints(ruler)) == 0 {
+		return nil, &parse.ParseError{Day: 5, Line: drawing.Line + last, Col: 1, Text: ruler, Cause: errors.New("drawing must end with a line numbering the stacks")}
+	}
+
+	for i, n := range parse.Ints(ruler) {
+		if n != i+1 {
+			col := strings.Index(ruler, strconv.Itoa(n)) + 1
+			return nil, &parse.ParseError{Day: 5, Line: drawing.Line + last, Col: col, Text: ruler, Cause: fmt.Errorf("stack %v is out of order, want %v", n, i+1)}
+		}
+		w.stacks = append(w.stacks, NewStack())
+	}
+
+	for i, line := range drawing.Lines[:last] {
+		for stack, mark := range parse.Columns(line, 4) {
+			mark = strings.TrimRight(mark, " ")
+			col := stack*4 + 1
+			if mark == "" {
+				continue
+			}
+
+			if len(mark) != 3 || mark[0] != '[' || mark[2] != ']' || mark[1] == ' ' {
+				return nil, &parse.ParseError{Day: 5, Line: drawing.Line + i, Col: col, Text: line, Cause: fmt.Errorf("want a crate like [A] or blanks, got %q", mark)}
+			}
+
+			if stack >= len(w.stacks) {
+				return nil, &parse.ParseError{Day: 5, Line: drawing.Line + i, Col: col, Text: line, Cause: fmt.Errorf("crate is outside the %v numbered stacks", len(w.stacks))}
+			}
+
+			w.pushToStack(stack+1, string(mark[1]))
+		}
+	}
+
+	return w, nil
+}
+
+// checkMove reports a step that takes more crates than its stack holds.
+func checkMove(w *warehouse, p Procedure) error {
+	if held := w.stacks[p.origStack-1].count; p.quantity > held {
+		m := ProcedureRX.FindStringSubmatchIndex(p.text)
+		col := m[2*ProcedureRX.SubexpIndex("quantity")] + 1
+		return &parse.ParseError{Day: 5, Line: p.line, Col: col, Text: p.text, Cause: fmt.Errorf("cannot move %v crates, stack %v holds %v", p.quantity, p.origStack, held)}
+	}
+	return nil
+}
+
+func partOne(warehouse *warehouse, procedures []Procedure) (string, error) {
+	for _, p := range procedures {
+		if err := checkMove(warehouse, p); err != nil {
+			return "", err
+		}
+		warehouse.move(p.origStack, p.destStack, p.quantity)
+	}
+
+	return warehouse.topMarks(), nil
+}
+
+func partTwo(warehouse *warehouse, procedures []Procedure) (string, error) {
+
+	for _, p := range procedures {
+		if err := checkMove(warehouse, p); err != nil {
+			return "", err
+		}
+		warehouse.moveMultiple(p.origStack, p.destStack, p.quantity)
+	}
+
+	return warehouse.topMarks(), nil
+}
+
+func init() {
+	solver.Register(5, Solver{})
+}
+
+// Solver answers day 5 from a drawing of the stacks and a rearrangement
+// procedure.
+type Solver struct{}
+
+func (Solver) PartOne(r io.Reader) (string, error) {
+	warehouse, procedures, err := parseInput(r)
+	if err != nil {
+		return "", err
+	}
+	return partOne(warehouse, procedures)
+}
+
+func (Solver) PartTwo(r io.Reader) (string, error) {
+	warehouse, procedures, err := parseInput(r)
+	if err != nil {
+		return "", err
+	}
+	return partTwo(warehouse, procedures)
+}
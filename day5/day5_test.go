@@ -0,0 +1,49 @@
+package day5
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/asc521/aoc-2022/parse"
+	"github.com/asc521/aoc-2022/solver"
+
+	"github.com/asc521/aoc-2022/solver/solvertest"
+)
+
+func TestSolver(t *testing.T) {
+	solvertest.Run(t, Solver{}, []solvertest.Case{
+		{Name: "example part 1", File: "example.txt", Part: 1, Want: "CMZ"},
+		{Name: "example part 2", File: "example.txt", Part: 2, Want: "MCD"},
+	})
+}
+
+func TestMoveTooMany(t *testing.T) {
+	const input = "[A]\n[B] [C]\n 1   2\n\nmove 1 from 2 to 1\nmove 4 from 1 to 2\n"
+	for _, part := range []int{1, 2} {
+		_, err := solver.Solve(Solver{}, part, strings.NewReader(input))
+		var pe *parse.ParseError
+		if !errors.As(err, &pe) || pe.Line != 6 || pe.Col != 6 {
+			t.Errorf("part %v: got %v, want a parse error at 6:6", part, err)
+		}
+	}
+}
+
+func TestProcedureLines(t *testing.T) {
+	tests := []struct {
+		step string
+		col  int
+	}{
+		{"move 1 from 2 to 3xyz", 1},
+		{"move 1 from 2 to 123", 18},
+		{"move 100 from 1 to 2", 6},
+	}
+	for _, tt := range tests {
+		input := "[A]\n[B] [C]\n 1   2\n\n" + tt.step + "\n"
+		_, err := solver.Solve(Solver{}, 1, strings.NewReader(input))
+		var pe *parse.ParseError
+		if !errors.As(err, &pe) || pe.Line != 5 || pe.Col != tt.col {
+			t.Errorf("%q: got %v, want a parse error at 5:%v", tt.step, err, tt.col)
+		}
+	}
+}
@@ -1,8 +1,13 @@
-package main
+package day6
 
 import (
 	"fmt"
-	"io/ioutil"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/asc521/aoc-2022/solver"
 )
 
 /*
@@ -102,20 +107,31 @@ func startOfStream(stream string, dc int) int {
 	return -1
 }
 
-func main() {
+func init() {
+	solver.Register(6, Solver{})
+}
+
+// Solver answers day 6 from a datastream buffer.
+type Solver struct{}
+
+func (Solver) PartOne(r io.Reader) (string, error) {
+	return markerLocation(r, 4)
+}
+
+func (Solver) PartTwo(r io.Reader) (string, error) {
+	return markerLocation(r, 14)
+}
 
-	part := 2
-	message, err := ioutil.ReadFile("day6/input.txt")
+func markerLocation(r io.Reader, distinctChars int) (string, error) {
+	message, err := io.ReadAll(r)
 	if err != nil {
-		panic(err)
+		return "", err
 	}
 
-	if part == 1 {
-		loc := startOfStream(string(message), 4)
-		fmt.Println(loc)
-	} else if part == 2 {
-		loc := startOfStream(string(message), 14)
-		fmt.Println(loc)
+	stream := strings.TrimSpace(string(message))
+	loc := startOfStream(stream, distinctChars)
+	if loc < 0 {
+		return "", fmt.Errorf("day6: no marker of %v distinct characters in %v characters", distinctChars, utf8.RuneCountInString(stream))
 	}
-
+	return strconv.Itoa(loc), nil
 }
@@ -0,0 +1,34 @@
+package day6
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/asc521/aoc-2022/solver/solvertest"
+)
+
+func TestSolver(t *testing.T) {
+	solvertest.Run(t, Solver{}, []solvertest.Case{
+		{Name: "mjqj part 1", Input: "mjqjpqmgbljsphdztnvjfqwrcgsmlb", Part: 1, Want: "7"},
+		{Name: "bvwb part 1", Input: "bvwbjplbgvbhsrlpgdmjqwftvncz", Part: 1, Want: "5"},
+		{Name: "nppd part 1", Input: "nppdvjthqldpwncqszvftbrmjlhg", Part: 1, Want: "6"},
+		{Name: "nznr part 1", Input: "nznrnfrfntjfmvfwmzdfjlvtqnbhcprsg", Part: 1, Want: "10"},
+		{Name: "zcfz part 1", Input: "zcfzfwzzqfrljwzlrfnpqdbhtmscgvjw", Part: 1, Want: "11"},
+		{Name: "mjqj part 2", Input: "mjqjpqmgbljsphdztnvjfqwrcgsmlb", Part: 2, Want: "19"},
+		{Name: "bvwb part 2", Input: "bvwbjplbgvbhsrlpgdmjqwftvncz", Part: 2, Want: "23"},
+		{Name: "nppd part 2", Input: "nppdvjthqldpwncqszvftbrmjlhg", Part: 2, Want: "23"},
+		{Name: "nznr part 2", Input: "nznrnfrfntjfmvfwmzdfjlvtqnbhcprsg", Part: 2, Want: "29"},
+		{Name: "zcfz part 2", Input: "zcfzfwzzqfrljwzlrfnpqdbhtmscgvjw", Part: 2, Want: "26"},
+	})
+}
+
+func TestNoMarker(t *testing.T) {
+	for _, input := range []string{"", "abc", "abababababababababab"} {
+		for _, s := range []func(r io.Reader) (string, error){Solver{}.PartOne, Solver{}.PartTwo} {
+			if got, err := s(strings.NewReader(input)); err == nil {
+				t.Errorf("%q: got %v, want an error", input, got)
+			}
+		}
+	}
+}
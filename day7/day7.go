@@ -1,13 +1,18 @@
-package main
+package day7
 
 import (
 	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
-	"os"
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/asc521/aoc-2022/logger"
+	"github.com/asc521/aoc-2022/parse"
+	"github.com/asc521/aoc-2022/solver"
 )
 
 /*
@@ -139,9 +144,14 @@ func (d *Directory) AddFile(name string, size int) {
 	d.files[name] = NewFile(name, size)
 }
 
+// Show logs the tree under d at debug level.
 func (d *Directory) Show(depth int) {
+	if !logger.Enabled(logger.Debug) {
+		return
+	}
+
 	pad := strings.Repeat("  ", depth)
-	fmt.Printf("%v - %v (dir, size=%v)\n", pad, d.name, d.Size())
+	logger.Debugf("%v - %v (dir, size=%v)", pad, d.name, d.Size())
 
 	for _, s := range d.subDirectories {
 		s.Show(depth + 1)
@@ -150,7 +160,7 @@ func (d *Directory) Show(depth int) {
 	for _, f := range d.files {
 		pad = strings.Repeat("  ", depth+1)
 		fileInfo := "file, size=" + strconv.Itoa(f.size)
-		fmt.Printf("%v - %v (%v)\n", pad, f.name, fileInfo)
+		logger.Debugf("%v - %v (%v)", pad, f.name, fileInfo)
 	}
 
 }
@@ -210,34 +220,43 @@ type FileSystem struct {
 }
 
 func NewFileSytem() *FileSystem {
-	return &FileSystem{root: &Directory{
-		parent:         nil,
-		name:           "/",
-		files:          map[string]*File{},
-		subDirectories: map[string]*Directory{},
-	}}
+	return &FileSystem{root: NewDirectory("/", nil)}
 }
 
-func buildFileSystem(cmds io.Reader) *FileSystem {
+func buildFileSystem(cmds io.Reader) (*FileSystem, error) {
 	fileSystem := NewFileSytem()
 	currentLocation := fileSystem.root
+	lineNum := 0
 	r := bufio.NewReader(cmds)
 	for {
 		line, readErr := r.ReadBytes('\n')
 		if readErr != nil && readErr != io.EOF {
-			panic(readErr)
+			return nil, readErr
 		}
+		lineNum++
+		line = bytes.TrimRight(line, "\r\n")
 
 		switch {
+		case len(line) == 0:
+
 		case CDCmdRX.Match(line):
 
 			matches := CDCmdRX.FindStringSubmatch(string(line))
 			arg := matches[1]
 
 			if arg == ".." {
+				if currentLocation.parent == nil {
+					return nil, &parse.ParseError{Day: 7, Line: lineNum, Col: 6, Text: string(line), Cause: errors.New("cannot cd .. out of /")}
+				}
 				currentLocation = currentLocation.parent
-			} else if arg != "/" {
-				currentLocation = currentLocation.subDirectories[arg]
+			} else if arg == "/" {
+				currentLocation = fileSystem.root
+			} else {
+				sub, ok := currentLocation.subDirectories[arg]
+				if !ok {
+					return nil, &parse.ParseError{Day: 7, Line: lineNum, Col: 6, Text: string(line), Cause: fmt.Errorf("no directory %q has been listed in %v", arg, currentLocation.name)}
+				}
+				currentLocation = sub
 			}
 
 		case LSCmdRX.Match(line):
@@ -250,9 +269,11 @@ func buildFileSystem(cmds io.Reader) *FileSystem {
 			name := matches[2]
 			size, err := strconv.Atoi(matches[1])
 			if err != nil {
-				panic(err)
+				return nil, &parse.ParseError{Day: 7, Line: lineNum, Col: 1, Text: string(line), Cause: err}
 			}
 			currentLocation.AddFile(name, size)
+		default:
+			return nil, &parse.ParseError{Day: 7, Line: lineNum, Col: 1, Text: string(line), Cause: errors.New("not a command, directory or file")}
 		}
 
 		if readErr == io.EOF {
@@ -260,24 +281,37 @@ func buildFileSystem(cmds io.Reader) *FileSystem {
 		}
 	}
 
-	return fileSystem
+	return fileSystem, nil
+}
+
+func init() {
+	solver.Register(7, Solver{})
 }
 
-func main() {
+// Solver answers day 7 from a terminal session browsing the filesystem.
+type Solver struct{}
 
-	file, err := os.Open("/Users/alex.curto/code/aoc-2022/day7/input.txt")
+func (Solver) PartOne(r io.Reader) (string, error) {
+
+	fileSystem, err := buildFileSystem(r)
 	if err != nil {
-		panic(err)
+		return "", err
 	}
-	defer file.Close()
 
-	fileSystem := buildFileSystem(file)
 	fileSystem.root.Show(0)
 	totalSize := 0
 	for _, dir := range fileSystem.root.FilterMaxSize(100000) {
 		totalSize += dir.Size()
 	}
-	fmt.Println(totalSize)
+	return strconv.Itoa(totalSize), nil
+}
+
+func (Solver) PartTwo(r io.Reader) (string, error) {
+
+	fileSystem, err := buildFileSystem(r)
+	if err != nil {
+		return "", err
+	}
 
 	totalAvailableSpace := 70000000
 	freeSpace := totalAvailableSpace - fileSystem.root.Size()
@@ -297,6 +331,6 @@ func main() {
 		}
 	}
 
-	fmt.Printf("Name: %v Size: %v\n", smallest.name, smallest.Size())
-
+	logger.Infof("Name: %v Size: %v", smallest.name, smallest.Size())
+	return strconv.Itoa(smallest.Size()), nil
 }
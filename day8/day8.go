@@ -1,12 +1,15 @@
-package main
+package day8
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
-	"os"
+	"io"
 	"sort"
 	"strconv"
-	"strings"
+
+	"github.com/asc521/aoc-2022/grid"
+	"github.com/asc521/aoc-2022/parse"
+	"github.com/asc521/aoc-2022/solver"
 )
 
 /*
@@ -53,180 +56,132 @@ func NewTree(h int) *Tree {
 }
 
 type Forest struct {
-	trees [][]*Tree
-}
-
-func NewForest() *Forest {
-	return &Forest{
-		trees: [][]*Tree{},
-	}
-}
-
-func (f *Forest) AddTree(height, row int) {
-
-	if len(f.trees) < row+1 {
-		f.trees = append(f.trees, []*Tree{})
-	}
-
-	f.trees[row] = append(f.trees[row], NewTree(height))
+	trees *grid.Grid[*Tree]
 }
 
 func (f *Forest) String() string {
-	str := ""
-	for _, r := range f.trees {
-		for _, t := range r {
-			str += strconv.Itoa(t.height)
-		}
-		str += "\n"
-	}
-
-	return str
+	return f.trees.Render(func(_ grid.Point, t *Tree) rune {
+		return rune('0' + t.height)
+	})
 }
 
 func (f *Forest) Visible(row, col int) bool {
 
-	t := f.trees[row][col]
-	if row == 0 || row == len(f.trees[row])-1 || col == 0 || col == len(f.trees)-1 {
-		return true
-	}
-
-	top := 0
-	for i := row - 1; i >= 0; i-- {
-		if top < f.trees[i][col].height {
-			top = f.trees[i][col].height
-		}
+	p := grid.Point{Row: row, Col: col}
+	t, ok := f.trees.At(p)
+	if !ok {
+		return false
 	}
 
-	down := 0
-	for i := row + 1; i <= len(f.trees)-1; i++ {
-		if down < f.trees[i][col].height {
-			down = f.trees[i][col].height
-		}
+	if f.trees.OnEdge(p) {
+		return true
 	}
 
-	right := 0
-	for i := col - 1; i >= 0; i-- {
-		if right < f.trees[row][i].height {
-			right = f.trees[row][i].height
+	for _, dir := range grid.Orthogonal {
+		tallest := 0
+		for _, q := range f.trees.Ray(p, dir) {
+			other, _ := f.trees.At(q)
+			if tallest < other.height {
+				tallest = other.height
+			}
 		}
-	}
 
-	left := 0
-	for i := col + 1; i <= len(f.trees[row])-1; i++ {
-		if left < f.trees[row][i].height {
-			left = f.trees[row][i].height
+		if tallest < t.height {
+			return true
 		}
 	}
 
-	if top < t.height || down < t.height || right < t.height || left < t.height {
-		return true
-	} else {
-		return false
-	}
-
+	return false
 }
 
 func (f *Forest) TreesVisibleFromOutside() []*Tree {
 
 	visibleTrees := []*Tree{}
-	for row := 0; row <= len(f.trees)-1; row++ {
-		for col := 0; col <= len(f.trees[row])-1; col++ {
-			t := f.trees[row][col]
-			if f.Visible(row, col) {
-				visibleTrees = append(visibleTrees, t)
-			}
-
+	f.trees.Each(func(p grid.Point, t *Tree) {
+		if f.Visible(p.Row, p.Col) {
+			visibleTrees = append(visibleTrees, t)
 		}
-
-	}
+	})
 	return visibleTrees
 }
 
 func (f *Forest) ScenicScore(row, col int) int {
-	t := f.trees[row][col]
-	if row == 0 || row == len(f.trees[row])-1 || col == 0 || col == len(f.trees)-1 {
+	p := grid.Point{Row: row, Col: col}
+	t, ok := f.trees.At(p)
+	if !ok || f.trees.OnEdge(p) {
 		return 0
 	}
 
-	top := 0
-	for i := row - 1; i >= 0; i-- {
-		top++
-		if t.height <= f.trees[i][col].height {
-			break
+	score := 1
+	for _, dir := range grid.Orthogonal {
+		viewing := 0
+		for _, q := range f.trees.Ray(p, dir) {
+			viewing++
+			if other, _ := f.trees.At(q); t.height <= other.height {
+				break
+			}
 		}
+		score *= viewing
 	}
 
-	down := 0
-	for i := row + 1; i <= len(f.trees)-1; i++ {
-		down++
-		if t.height <= f.trees[i][col].height {
-			break
-		}
-
-	}
+	return score
+}
 
-	left := 0
-	for i := col - 1; i >= 0; i-- {
-		left++
-		if t.height <= f.trees[row][i].height {
-			break
-		}
+func (f *Forest) ComputeScenicScores() []int {
+	scores := []int{}
+	f.trees.Each(func(p grid.Point, _ *Tree) {
+		scores = append(scores, f.ScenicScore(p.Row, p.Col))
+	})
+	sort.Sort(sort.Reverse(sort.IntSlice(scores)))
+	return scores
+}
 
-	}
+func readForest(r io.Reader) (*Forest, error) {
 
-	right := 0
-	for i := col + 1; i <= len(f.trees[row])-1; i++ {
-		right++
-		if t.height <= f.trees[row][i].height {
-			break
+	trees, err := grid.Parse(r, func(_ grid.Point, ch rune) (*Tree, error) {
+		if ch < '0' || ch > '9' {
+			return nil, fmt.Errorf("tree height must be a digit, got %q", ch)
 		}
+		return NewTree(int(ch - '0')), nil
+	})
+	if err != nil {
+		var pe *parse.ParseError
+		if errors.As(err, &pe) {
+			pe.Day = 8
+		}
+		return nil, err
 	}
 
-	return top * right * down * left
+	return &Forest{trees: trees}, nil
 }
 
-func (f *Forest) ComputeScenicScores() []int {
-	scores := []int{}
-	for row := 0; row <= len(f.trees)-1; row++ {
-		for col := 0; col <= len(f.trees[row])-1; col++ {
-			score := f.ScenicScore(row, col)
-			scores = append(scores, score)
-		}
-
-	}
-	sort.Sort(sort.Reverse(sort.IntSlice(scores)))
-	return scores
+func init() {
+	solver.Register(8, Solver{})
 }
 
-func main() {
+// Solver answers day 8 from a map of tree heights.
+type Solver struct{}
 
-	file, err := os.Open("/Users/alex.curto/code/aoc-2022/day8/input.txt")
+func (Solver) PartOne(r io.Reader) (string, error) {
+	forest, err := readForest(r)
 	if err != nil {
-		panic(err)
+		return "", err
 	}
-	defer file.Close()
-
-	forest := NewForest()
-	scanner := bufio.NewScanner(file)
-	rowNum := 0
-	for scanner.Scan() {
-		row := scanner.Text()
-		nodes := strings.Split(row, "")
-		for _, t := range nodes {
-			h, err := strconv.Atoi(t)
-			if err != nil {
-				panic(err)
-			}
-			forest.AddTree(h, rowNum)
-		}
 
-		rowNum++
+	trees := forest.TreesVisibleFromOutside()
+	return strconv.Itoa(len(trees)), nil
+}
 
+func (Solver) PartTwo(r io.Reader) (string, error) {
+	forest, err := readForest(r)
+	if err != nil {
+		return "", err
 	}
 
-	trees := forest.TreesVisibleFromOutside()
-	fmt.Println(len(trees))
+	// An empty forest has no trees and so no scenic score above 0.
 	scores := forest.ComputeScenicScores()
-	fmt.Println(scores[0])
-
+	if len(scores) == 0 {
+		return "0", nil
+	}
+	return strconv.Itoa(scores[0]), nil
 }
@@ -0,0 +1,25 @@
+package day8
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/asc521/aoc-2022/solver/solvertest"
+)
+
+func TestSolver(t *testing.T) {
+	solvertest.Run(t, Solver{}, []solvertest.Case{
+		{Name: "example part 1", File: "example.txt", Part: 1, Want: "21"},
+		{Name: "example part 2", File: "example.txt", Part: 2, Want: "8"},
+		{Name: "non-square part 1", Input: "303\n255\n653\n335\n", Part: 1, Want: "12"},
+		{Name: "non-square part 2", Input: "30373\n25512\n65332\n", Part: 2, Want: "2"},
+		{Name: "empty part 1", Input: "", Part: 1, Want: "0"},
+		{Name: "empty part 2", Input: "", Part: 2, Want: "0"},
+	})
+}
+
+func TestBlankLines(t *testing.T) {
+	if _, err := (Solver{}).PartTwo(strings.NewReader("\n\n")); err == nil {
+		t.Error("PartTwo accepted a forest of blank lines")
+	}
+}
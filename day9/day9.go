@@ -1,11 +1,16 @@
-package main
+package day9
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
-	"os"
+	"io"
 	"strconv"
 	"strings"
+
+	"github.com/asc521/aoc-2022/logger"
+	"github.com/asc521/aoc-2022/parse"
+	"github.com/asc521/aoc-2022/solver"
 )
 
 /*
@@ -384,8 +389,7 @@ func (b *Bridge) AddPlanck(name string) {
 }
 
 func (b *Bridge) MoveHead(direction Direction, amount int) {
-	move := fmt.Sprintf("****** %v %v ******\n", direction, amount)
-	fmt.Printf(move)
+	logger.Tracef("****** %v %v ******", direction, amount)
 	for i := 1; i <= amount; i++ {
 		switch direction {
 		case Right:
@@ -403,9 +407,6 @@ func (b *Bridge) MoveHead(direction Direction, amount int) {
 
 		h := b.head
 		for h.tail != nil {
-			if h.name == "9" {
-				fmt.Println("Debug")
-			}
 			b.MoveTail(h)
 			h = h.tail
 		}
@@ -413,7 +414,7 @@ func (b *Bridge) MoveHead(direction Direction, amount int) {
 		b.Show()
 
 	}
-	fmt.Printf(move)
+	logger.Tracef("****** %v %v ******", direction, amount)
 
 }
 
@@ -467,16 +468,21 @@ func (b *Bridge) MoveTail(head *Planck) {
 	}
 }
 
+// Show logs the position of every knot at trace level.
 func (b *Bridge) Show() {
+	if !logger.Enabled(logger.Trace) {
+		return
+	}
 
+	var sb strings.Builder
 	if b.head != nil {
 		p := b.head
 		for p.tail != nil {
-			fmt.Printf("{name: %v {x: %v y: %v }} ", p.name, p.pos.x, p.pos.y)
+			fmt.Fprintf(&sb, "{name: %v {x: %v y: %v }} ", p.name, p.pos.x, p.pos.y)
 			p = p.tail
 		}
 	}
-	fmt.Println()
+	logger.Tracef("%v", sb.String())
 }
 
 func ContainsDistance(distances []Distance, f Distance) bool {
@@ -489,15 +495,25 @@ func ContainsDistance(distances []Distance, f Distance) bool {
 	return false
 }
 
-func main() {
+func init() {
+	solver.Register(9, Solver{})
+}
 
-	bridge := NewBridge()
-	file, err := os.Open("/Users/alex.curto/code/aoc-2022/day9/input.txt")
-	if err != nil {
-		panic(err)
-	}
+// Solver answers day 9 from a series of motions for the head of the rope.
+type Solver struct{}
+
+func (Solver) PartOne(r io.Reader) (string, error) {
+	return tailPositions(r, 2)
+}
 
-	for i := 0; i < 10; i++ {
+func (Solver) PartTwo(r io.Reader) (string, error) {
+	return tailPositions(r, 10)
+}
+
+func tailPositions(r io.Reader, knots int) (string, error) {
+
+	bridge := NewBridge()
+	for i := 0; i < knots; i++ {
 		var name string
 		if i == 0 {
 			name = "H"
@@ -509,10 +525,16 @@ func main() {
 
 	bridge.Show()
 
-	scanner := bufio.NewScanner(file)
+	lineNum := 0
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		line := scanner.Text()
+		lineNum++
 		args := strings.Split(line, " ")
+		if len(args) != 2 {
+			return "", &parse.ParseError{Day: 9, Line: lineNum, Col: 1, Text: line, Cause: errors.New(`want a motion like "R 4"`)}
+		}
+
 		var direction Direction
 		switch args[0] {
 		case "R":
@@ -524,24 +546,32 @@ func main() {
 		case "D":
 			direction = Down
 		default:
-			panic("Parsed a direction I do not support")
+			return "", &parse.ParseError{Day: 9, Line: lineNum, Col: 1, Text: line, Cause: fmt.Errorf("unknown direction %q, want R, U, L or D", args[0])}
 		}
 
 		amount, err := strconv.Atoi(args[1])
-		if err != nil {
-			panic(err)
+		if err != nil || amount < 0 {
+			return "", &parse.ParseError{Day: 9, Line: lineNum, Col: len(args[0]) + 2, Text: line, Cause: fmt.Errorf("step count must be a non-negative number, got %q", args[1])}
 		}
 
 		bridge.MoveHead(direction, amount)
 	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
 
 	bridge.PrintHits()
-	fmt.Println(len(bridge.tailPositionLog))
-
+	return strconv.Itoa(len(bridge.tailPositionLog)), nil
 }
 
+// PrintHits logs a map of the area around the start at debug level, with
+// the cells the tail visited marked.
 func (b *Bridge) PrintHits() {
+	if !logger.Enabled(logger.Debug) {
+		return
+	}
 
+	var sb strings.Builder
 	top := 14
 	bottom := -5
 	left := -11
@@ -554,13 +584,14 @@ func (b *Bridge) PrintHits() {
 			key := fmt.Sprintf("{ x: %v, y: %v }", x, y)
 
 			if x == 0 && y == 0 {
-				fmt.Print("s")
+				sb.WriteByte('s')
 			} else if b.tailPositionLog[key] {
-				fmt.Print("#")
+				sb.WriteByte('#')
 			} else {
-				fmt.Print(".")
+				sb.WriteByte('.')
 			}
 		}
-		fmt.Println()
+		sb.WriteByte('\n')
 	}
+	logger.Debugf("%v", sb.String())
 }
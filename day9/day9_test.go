@@ -0,0 +1,15 @@
+package day9
+
+import (
+	"testing"
+
+	"github.com/asc521/aoc-2022/solver/solvertest"
+)
+
+func TestSolver(t *testing.T) {
+	solvertest.Run(t, Solver{}, []solvertest.Case{
+		{Name: "example part 1", File: "example.txt", Part: 1, Want: "13"},
+		{Name: "example part 2", File: "example.txt", Part: 2, Want: "1"},
+		{Name: "larger example part 2", File: "example_2.txt", Part: 2, Want: "36"},
+	})
+}
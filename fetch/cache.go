@@ -0,0 +1,109 @@
+package fetch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Cache stores documents by the SHA-256 of their content under dir/objects
+// and keeps an index from day and kind to content hash. Anything already in
+// the index is served locally and never fetched again.
+type Cache struct {
+	dir  string
+	next Fetcher
+
+	mu    sync.Mutex
+	index map[string]string
+}
+
+// OpenCache opens or creates a cache in dir. next is used for misses; with a
+// nil next the cache is offline and misses return ErrOffline.
+func OpenCache(dir string, next Fetcher) (*Cache, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "objects"), 0o755); err != nil {
+		return nil, err
+	}
+
+	c := &Cache{dir: dir, next: next, index: map[string]string{}}
+
+	data, err := os.ReadFile(c.indexPath())
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &c.index); err != nil {
+		return nil, fmt.Errorf("fetch: reading %v: %w", c.indexPath(), err)
+	}
+	return c, nil
+}
+
+func (c *Cache) indexPath() string {
+	return filepath.Join(c.dir, "index.json")
+}
+
+func (c *Cache) objectPath(hash string) string {
+	return filepath.Join(c.dir, "objects", hash)
+}
+
+func indexKey(day int, kind Kind) string {
+	return fmt.Sprintf("%d/day%d/%v", Year, day, kind)
+}
+
+// Cached reports whether a document is already stored.
+func (c *Cache) Cached(day int, kind Kind) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.index[indexKey(day, kind)]
+	return ok
+}
+
+func (c *Cache) Fetch(ctx context.Context, day int, kind Kind) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := indexKey(day, kind)
+	if hash, ok := c.index[key]; ok {
+		data, err := os.ReadFile(c.objectPath(hash))
+		if err != nil {
+			return nil, fmt.Errorf("fetch: cached %v: %w", key, err)
+		}
+		return data, nil
+	}
+
+	if c.next == nil {
+		return nil, fmt.Errorf("%w: %v", ErrOffline, key)
+	}
+
+	data, err := c.next.Fetch(ctx, day, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	if err := os.WriteFile(c.objectPath(hash), data, 0o644); err != nil {
+		return nil, err
+	}
+
+	c.index[key] = hash
+	return data, c.saveIndex()
+}
+
+func (c *Cache) saveIndex() error {
+	data, err := json.MarshalIndent(c.index, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.indexPath(), append(data, '\n'), 0o644)
+}
@@ -0,0 +1,64 @@
+package fetch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Config holds the settings for talking to the site. It is read from a JSON
+// file:
+//
+//	{"session": "53616c74...", "cache_dir": "/home/me/.cache/aoc-2022"}
+type Config struct {
+	// Session is the value of the site's session cookie.
+	Session string `json:"session"`
+
+	// CacheDir is where fetched documents are kept.
+	CacheDir string `json:"cache_dir,omitempty"`
+
+	// BaseURL overrides DefaultBaseURL, for example to point at a local
+	// NewServer.
+	BaseURL string `json:"base_url,omitempty"`
+}
+
+// DefaultConfigPath returns aoc-2022/config.json in the user's config
+// directory.
+func DefaultConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "aoc-2022", "config.json"), nil
+}
+
+// LoadConfig reads the config at path. A missing file is an empty config,
+// which is enough to work from the cache. Empty fields are given defaults.
+func LoadConfig(path string) (Config, error) {
+	var c Config
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return c, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &c); err != nil {
+			return c, fmt.Errorf("fetch: reading %v: %w", path, err)
+		}
+	}
+
+	if c.BaseURL == "" {
+		c.BaseURL = DefaultBaseURL
+	}
+	if c.CacheDir == "" {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return c, err
+		}
+		c.CacheDir = filepath.Join(dir, "aoc-2022")
+	}
+	return c, nil
+}
@@ -0,0 +1,164 @@
+// Package fetch downloads puzzle inputs and descriptions into a local,
+// content-addressed cache so each day is only ever requested once.
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Year is the event the solutions in this repository belong to.
+const Year = 2022
+
+// DefaultBaseURL is the Advent of Code site.
+const DefaultBaseURL = "https://adventofcode.com"
+
+// DefaultInterval is the minimum time between two requests to the site.
+const DefaultInterval = 5 * time.Second
+
+// Kind selects what to fetch for a day.
+type Kind string
+
+const (
+	Input       = Kind("input")
+	Description = Kind("description")
+)
+
+// ErrOffline is returned when something is not cached and there is nothing
+// to fetch it with.
+var ErrOffline = errors.New("fetch: not cached and running offline")
+
+// Fetcher retrieves one kind of document for a day.
+type Fetcher interface {
+	Fetch(ctx context.Context, day int, kind Kind) ([]byte, error)
+}
+
+// Path returns the site path for a day's document.
+func Path(day int, kind Kind) string {
+	p := fmt.Sprintf("/%d/day/%d", Year, day)
+	if kind == Input {
+		p += "/input"
+	}
+	return p
+}
+
+// HTTPFetcher fetches from the Advent of Code site, or anything serving the
+// same paths, authenticating with a session cookie.
+type HTTPFetcher struct {
+	BaseURL string
+	Session string
+	Client  *http.Client
+	Limiter *Limiter
+}
+
+func (f *HTTPFetcher) Fetch(ctx context.Context, day int, kind Kind) ([]byte, error) {
+	if f.Session == "" {
+		return nil, errors.New("fetch: no session cookie configured")
+	}
+
+	if f.Limiter != nil {
+		if err := f.Limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	base := f.BaseURL
+	if base == "" {
+		base = DefaultBaseURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(base, "/")+Path(day, kind), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.AddCookie(&http.Cookie{Name: "session", Value: f.Session})
+	req.Header.Set("User-Agent", "github.com/asc521/aoc-2022")
+
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch: GET %v: %v: %v", req.URL, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return body, nil
+}
+
+// Limiter spaces requests at least Interval apart. When StatePath is set the
+// time of the last request is kept in that file's modification time, so the
+// limit also holds across separate runs.
+type Limiter struct {
+	Interval  time.Duration
+	StatePath string
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+// NewLimiter returns a Limiter for interval that remembers its last request
+// in statePath, if not empty.
+func NewLimiter(interval time.Duration, statePath string) *Limiter {
+	return &Limiter{Interval: interval, StatePath: statePath}
+}
+
+// Wait blocks until a request may be made and records that one is.
+func (l *Limiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	last := l.last
+	if l.StatePath != "" {
+		if fi, err := os.Stat(l.StatePath); err == nil && fi.ModTime().After(last) {
+			last = fi.ModTime()
+		}
+	}
+
+	if wait := time.Until(last.Add(l.Interval)); wait > 0 {
+		t := time.NewTimer(wait)
+		defer t.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+
+	l.last = time.Now()
+	if l.StatePath != "" {
+		if err := touch(l.StatePath, l.last); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func touch(path string, t time.Time) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Chtimes(path, t, t)
+}
@@ -0,0 +1,169 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheFetchesOnce(t *testing.T) {
+	site := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(site, "day6"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(site, "day6", "input.txt"), []byte("mjqjpqmgbljsphdztnvjfqwrcgsmlb\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var hits atomic.Int32
+	handler := NewServer(site)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		handler.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	online := &HTTPFetcher{BaseURL: srv.URL, Session: "test", Client: srv.Client()}
+	c, err := OpenCache(dir, online)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		got, err := c.Fetch(ctx, 6, Input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(string(got), "mjqjpqm") {
+			t.Errorf("Fetch #%v = %q", i+1, got)
+		}
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("server hit %v times, want 1", n)
+	}
+
+	if _, err := c.Fetch(ctx, 7, Input); err == nil {
+		t.Errorf("Fetch of a day the server does not have succeeded")
+	}
+
+	offline, err := OpenCache(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := offline.Fetch(ctx, 6, Input); err != nil {
+		t.Errorf("offline Fetch of a cached day: %v", err)
+	}
+	if _, err := offline.Fetch(ctx, 6, Description); !errors.Is(err, ErrOffline) {
+		t.Errorf("offline Fetch of an uncached document = %v, want %v", err, ErrOffline)
+	}
+}
+
+func TestLimiterSpacesRequests(t *testing.T) {
+	site := t.TempDir()
+	for _, day := range []string{"day1", "day2"} {
+		if err := os.MkdirAll(filepath.Join(site, day), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(site, day, "input.txt"), []byte("1\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var mu sync.Mutex
+	var times []time.Time
+	handler := NewServer(site)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		times = append(times, time.Now())
+		mu.Unlock()
+		handler.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	const interval = 200 * time.Millisecond
+	state := filepath.Join(t.TempDir(), "last_request")
+	online := &HTTPFetcher{BaseURL: srv.URL, Session: "test", Client: srv.Client(), Limiter: NewLimiter(interval, state)}
+	c, err := OpenCache(t.TempDir(), online)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for _, day := range []int{1, 2} {
+		if _, err := c.Fetch(ctx, day, Input); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(times) != 2 {
+		t.Fatalf("server hit %v times, want 2", len(times))
+	}
+	// The limiter times the start of each request, so allow for the first
+	// one taking a little longer to reach the server.
+	if gap := times[1].Sub(times[0]); gap < interval*9/10 {
+		t.Errorf("back-to-back fetches %v apart, want about %v", gap, interval)
+	}
+
+	// Cached documents never reach the limiter, so they need not wait.
+	start := time.Now()
+	for _, day := range []int{1, 2} {
+		if _, err := c.Fetch(ctx, day, Input); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if took := time.Since(start); took >= interval {
+		t.Errorf("cached fetches took %v, want no wait", took)
+	}
+
+	// A new limiter sharing the state file still waits for the last request.
+	start = time.Now()
+	if err := NewLimiter(interval, state).Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if took := time.Since(start); took < interval/2 {
+		t.Errorf("limiter from saved state waited %v, want about %v", took, interval)
+	}
+}
+
+func TestServerNeedsSession(t *testing.T) {
+	srv := httptest.NewServer(NewServer(t.TempDir()))
+	defer srv.Close()
+
+	resp, err := srv.Client().Get(srv.URL + Path(1, Input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status without session = %v, want %v", resp.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestDescriptionText(t *testing.T) {
+	page := `<html><body><main>
+<article class="day-desc"><h2>--- Day 6: Tuning Trouble ---</h2><p>The preparations are <em>finally</em> complete &amp; you set off.</p>
+<ul><li>one</li><li>two</li></ul>
+<pre><code>mjqjpqmgbljsphdztnvjfqwrcgsmlb
+</code></pre>
+</article>
+<p>Answer form</p>
+</main></body></html>`
+
+	want := "--- Day 6: Tuning Trouble ---\n\n" +
+		"The preparations are finally complete & you set off.\n\n" +
+		"  - one\n  - two\n\n" +
+		"mjqjpqmgbljsphdztnvjfqwrcgsmlb"
+
+	if got := DescriptionText([]byte(page)); got != want {
+		t.Errorf("DescriptionText =\n%v\nwant\n%v", got, want)
+	}
+}
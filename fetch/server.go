@@ -0,0 +1,45 @@
+package fetch
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+var dayPathRX = regexp.MustCompile(`^/([0-9]+)/day/([0-9]+)(/input)?$`)
+
+// NewServer returns a handler that stands in for the Advent of Code site.
+// It serves dir/dayN/input.txt and dir/dayN/puzzle.html at the site's paths
+// and, like the site, refuses requests without a session cookie.
+func NewServer(dir string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m := dayPathRX.FindStringSubmatch(r.URL.Path)
+		if m == nil || m[1] != fmt.Sprint(Year) {
+			http.NotFound(w, r)
+			return
+		}
+
+		if c, err := r.Cookie("session"); err != nil || c.Value == "" {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+
+		name := "puzzle.html"
+		contentType := "text/html; charset=utf-8"
+		if m[3] != "" {
+			name = "input.txt"
+			contentType = "text/plain"
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, "day"+m[2], name))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Write(data)
+	})
+}
@@ -0,0 +1,55 @@
+package fetch
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	articleRX  = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	headingRX  = regexp.MustCompile(`(?s)<h2[^>]*>(.*?)</h2>`)
+	listItemRX = regexp.MustCompile(`<li[^>]*>`)
+	breakRX    = regexp.MustCompile(`</p>|</pre>|</ul>|<br\s*/?>`)
+	tagRX      = regexp.MustCompile(`<[^>]*>`)
+	blanksRX   = regexp.MustCompile(`\n{3,}`)
+)
+
+// DescriptionText turns a puzzle page into plain text. Only the puzzle
+// articles are kept; code blocks keep their layout and list items are
+// indented.
+func DescriptionText(page []byte) string {
+	articles := articleRX.FindAllSubmatch(page, -1)
+	if len(articles) == 0 {
+		articles = [][][]byte{{page, page}}
+	}
+
+	var b strings.Builder
+	for _, a := range articles {
+		s := string(a[1])
+		s = headingRX.ReplaceAllString(s, "$1\n\n")
+		s = listItemRX.ReplaceAllString(s, "  - ")
+		s = strings.ReplaceAll(s, "</li>", "\n")
+		s = breakRX.ReplaceAllString(s, "\n\n")
+		s = tagRX.ReplaceAllString(s, "")
+		b.WriteString(html.UnescapeString(s))
+		b.WriteString("\n\n")
+	}
+
+	return strings.TrimSpace(blanksRX.ReplaceAllString(b.String(), "\n\n"))
+}
+
+// DocFile renders a Go source file holding the description of day as the
+// package documentation of dayN.
+func DocFile(day int, description string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Code generated by aoc fetch; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "/*\nPackage day%v solves Advent of Code %v day %v.\n\n", day, Year, day)
+	for _, line := range strings.Split(strings.ReplaceAll(description, "*/", "* /"), "\n") {
+		b.WriteString(strings.TrimRight(line, " \t"))
+		b.WriteByte('\n')
+	}
+	fmt.Fprintf(&b, "\n%v%v\n*/\npackage day%v\n", DefaultBaseURL, Path(day, Description), day)
+	return b.String()
+}
@@ -0,0 +1,184 @@
+// Package grid is a generic, bounds-checked 2D grid for the map shaped
+// puzzles.
+package grid
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/asc521/aoc-2022/parse"
+)
+
+// Point is a cell position. Row 0 is the top of the grid and Col 0 the left.
+type Point struct {
+	Row, Col int
+}
+
+// Add returns p moved by d.
+func (p Point) Add(d Point) Point {
+	return Point{Row: p.Row + d.Row, Col: p.Col + d.Col}
+}
+
+func (p Point) String() string {
+	return fmt.Sprintf("(%v, %v)", p.Row, p.Col)
+}
+
+// Directions a ray can be cast in, also used as neighbour offsets.
+var (
+	Up    = Point{Row: -1}
+	Down  = Point{Row: 1}
+	Left  = Point{Col: -1}
+	Right = Point{Col: 1}
+
+	// Orthogonal are the four directions sharing an edge with a cell.
+	Orthogonal = []Point{Up, Down, Left, Right}
+
+	// Diagonal are the four directions sharing only a corner with a cell.
+	Diagonal = []Point{Up.Add(Left), Up.Add(Right), Down.Add(Left), Down.Add(Right)}
+)
+
+// Grid is a rectangular grid of T stored row by row.
+type Grid[T any] struct {
+	rows, cols int
+	cells      []T
+}
+
+// New returns a rows x cols grid of zero values.
+func New[T any](rows, cols int) *Grid[T] {
+	return &Grid[T]{rows: rows, cols: cols, cells: make([]T, rows*cols)}
+}
+
+// Parse reads one row per line, turning every character into a cell with
+// cell. Rows must all be the same length. Errors are *parse.ParseError with
+// the Day left for the caller to fill in.
+func Parse[T any](r io.Reader, cell func(p Point, ch rune) (T, error)) (*Grid[T], error) {
+	g := &Grid[T]{}
+	lineNum := 0
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+		lineNum++
+		chars := []rune(line)
+
+		if len(chars) == 0 {
+			return nil, &parse.ParseError{Line: lineNum, Text: line, Cause: errors.New("empty row")}
+		}
+
+		if g.rows > 0 && len(chars) != g.cols {
+			return nil, &parse.ParseError{Line: lineNum, Col: len(chars) + 1, Text: line, Cause: fmt.Errorf("row has %v cells, want %v", len(chars), g.cols)}
+		}
+
+		for col, ch := range chars {
+			v, err := cell(Point{Row: g.rows, Col: col}, ch)
+			if err != nil {
+				return nil, &parse.ParseError{Line: lineNum, Col: col + 1, Text: line, Cause: err}
+			}
+			g.cells = append(g.cells, v)
+		}
+
+		g.cols = len(chars)
+		g.rows++
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// Rows returns the number of rows.
+func (g *Grid[T]) Rows() int {
+	return g.rows
+}
+
+// Cols returns the number of columns.
+func (g *Grid[T]) Cols() int {
+	return g.cols
+}
+
+// In reports whether p is inside the grid.
+func (g *Grid[T]) In(p Point) bool {
+	return p.Row >= 0 && p.Row < g.rows && p.Col >= 0 && p.Col < g.cols
+}
+
+// OnEdge reports whether p is in the outermost ring of the grid.
+func (g *Grid[T]) OnEdge(p Point) bool {
+	return g.In(p) && (p.Row == 0 || p.Row == g.rows-1 || p.Col == 0 || p.Col == g.cols-1)
+}
+
+// At returns the cell at p, or the zero value and false outside the grid.
+func (g *Grid[T]) At(p Point) (T, bool) {
+	if !g.In(p) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[p.Row*g.cols+p.Col], true
+}
+
+// Set stores v at p and reports whether p was inside the grid.
+func (g *Grid[T]) Set(p Point, v T) bool {
+	if !g.In(p) {
+		return false
+	}
+	g.cells[p.Row*g.cols+p.Col] = v
+	return true
+}
+
+// Each calls fn for every cell, row by row.
+func (g *Grid[T]) Each(fn func(p Point, v T)) {
+	for i, v := range g.cells {
+		fn(Point{Row: i / g.cols, Col: i % g.cols}, v)
+	}
+}
+
+// Neighbors4 returns the cells sharing an edge with p that are inside the
+// grid.
+func (g *Grid[T]) Neighbors4(p Point) []Point {
+	return g.offsets(p, Orthogonal)
+}
+
+// Neighbors8 returns the cells sharing an edge or a corner with p that are
+// inside the grid.
+func (g *Grid[T]) Neighbors8(p Point) []Point {
+	return append(g.offsets(p, Orthogonal), g.offsets(p, Diagonal)...)
+}
+
+func (g *Grid[T]) offsets(p Point, dirs []Point) []Point {
+	n := []Point{}
+	for _, d := range dirs {
+		if q := p.Add(d); g.In(q) {
+			n = append(n, q)
+		}
+	}
+	return n
+}
+
+// Ray returns the cells from p towards the edge in direction dir, nearest
+// first. p itself is not included.
+func (g *Grid[T]) Ray(p Point, dir Point) []Point {
+	ray := []Point{}
+	if dir == (Point{}) {
+		return ray
+	}
+
+	for q := p.Add(dir); g.In(q); q = q.Add(dir) {
+		ray = append(ray, q)
+	}
+	return ray
+}
+
+// Render draws the grid one row per line using cell to pick each character.
+func (g *Grid[T]) Render(cell func(p Point, v T) rune) string {
+	var b strings.Builder
+	for row := 0; row < g.rows; row++ {
+		for col := 0; col < g.cols; col++ {
+			p := Point{Row: row, Col: col}
+			b.WriteRune(cell(p, g.cells[row*g.cols+col]))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
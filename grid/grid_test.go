@@ -0,0 +1,96 @@
+package grid
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/asc521/aoc-2022/parse"
+)
+
+func digits(_ Point, ch rune) (int, error) {
+	if ch < '0' || ch > '9' {
+		return 0, errors.New("not a digit")
+	}
+	return int(ch - '0'), nil
+}
+
+func TestParseAndRender(t *testing.T) {
+	g, err := Parse(strings.NewReader("123\n456\n"), digits)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if g.Rows() != 2 || g.Cols() != 3 {
+		t.Fatalf("size = %vx%v, want 2x3", g.Rows(), g.Cols())
+	}
+
+	if v, ok := g.At(Point{Row: 1, Col: 2}); !ok || v != 6 {
+		t.Errorf("At(1, 2) = %v, %v; want 6, true", v, ok)
+	}
+
+	if _, ok := g.At(Point{Row: 2, Col: 0}); ok {
+		t.Errorf("At(2, 0) is outside the grid but returned ok")
+	}
+
+	got := g.Render(func(_ Point, v int) rune { return rune('a' + v) })
+	if want := "bcd\nefg\n"; got != want {
+		t.Errorf("Render = %q, want %q", got, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		line     int
+		col      int
+		wantText string
+	}{
+		{"123\n45\n", 2, 3, "45"},
+		{"123\n4x6\n", 2, 2, "4x6"},
+		{"123\n\n", 2, 0, ""},
+	}
+	for _, tt := range tests {
+		_, err := Parse(strings.NewReader(tt.input), digits)
+		var pe *parse.ParseError
+		if !errors.As(err, &pe) {
+			t.Fatalf("Parse(%q) error = %v, want a ParseError", tt.input, err)
+		}
+		if pe.Line != tt.line || pe.Col != tt.col || pe.Text != tt.wantText {
+			t.Errorf("Parse(%q) error at line %v col %v text %q, want line %v col %v text %q",
+				tt.input, pe.Line, pe.Col, pe.Text, tt.line, tt.col, tt.wantText)
+		}
+	}
+}
+
+func TestNeighborsAndRays(t *testing.T) {
+	g := New[int](3, 4)
+	corner := Point{Row: 0, Col: 0}
+
+	if got := g.Neighbors4(corner); len(got) != 2 {
+		t.Errorf("Neighbors4(corner) = %v, want 2 cells", got)
+	}
+	if got := g.Neighbors8(corner); len(got) != 3 {
+		t.Errorf("Neighbors8(corner) = %v, want 3 cells", got)
+	}
+	if got := g.Neighbors8(Point{Row: 1, Col: 1}); len(got) != 8 {
+		t.Errorf("Neighbors8(middle) = %v, want 8 cells", got)
+	}
+
+	p := Point{Row: 1, Col: 1}
+	tests := []struct {
+		dir  Point
+		want []Point
+	}{
+		{Up, []Point{{0, 1}}},
+		{Down, []Point{{2, 1}}},
+		{Left, []Point{{1, 0}}},
+		{Right, []Point{{1, 2}, {1, 3}}},
+	}
+	for _, tt := range tests {
+		if got := g.Ray(p, tt.dir); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Ray(%v, %v) = %v, want %v", p, tt.dir, got, tt.want)
+		}
+	}
+}
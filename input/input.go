@@ -0,0 +1,160 @@
+// Package input locates a day's puzzle input.
+//
+// Inputs are resolved in order from an explicit path, the directory named by
+// the AOC_INPUT_DIR environment variable, the day's directory next to the
+// running binary, and finally standard input when it is not a terminal.
+package input
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// EnvDir names the environment variable holding a directory of dayN/input.txt
+// files.
+const EnvDir = "AOC_INPUT_DIR"
+
+// Stdin is the explicit path that selects standard input.
+const Stdin = "-"
+
+// Source is an opened puzzle input.
+type Source struct {
+	io.ReadCloser
+	// Name is the path the input was read from, or "stdin".
+	Name string
+}
+
+// Attempt records one place the resolver looked for an input.
+type Attempt struct {
+	Rule string
+	Path string
+	Err  error
+}
+
+// NotFoundError is returned when no rule produced an input. It names every
+// path that was tried.
+type NotFoundError struct {
+	Day      int
+	Attempts []Attempt
+}
+
+func (e *NotFoundError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "no input found for day %v; tried:", e.Day)
+	for _, a := range e.Attempts {
+		fmt.Fprintf(&b, "\n  %v: ", a.Rule)
+		if a.Path != "" {
+			fmt.Fprintf(&b, "%v: ", a.Path)
+		}
+		b.WriteString(errorText(a.Err))
+	}
+	return b.String()
+}
+
+// errorText drops the path os.PathError already repeats.
+func errorText(err error) string {
+	var pathErr *os.PathError
+	if errors.As(err, &pathErr) {
+		return pathErr.Err.Error()
+	}
+	return err.Error()
+}
+
+// Resolver finds inputs. The zero value is not usable; use NewResolver.
+type Resolver struct {
+	Getenv     func(string) string
+	Executable func() (string, error)
+	Stdin      *os.File
+}
+
+// NewResolver returns a Resolver backed by the process environment.
+func NewResolver() *Resolver {
+	return &Resolver{
+		Getenv:     os.Getenv,
+		Executable: os.Executable,
+		Stdin:      os.Stdin,
+	}
+}
+
+// Open resolves the input for day using the default Resolver.
+func Open(day int, explicit string) (*Source, error) {
+	return NewResolver().Open(day, explicit)
+}
+
+// FileName returns the conventional location of day's input relative to a
+// directory of day folders.
+func FileName(day int) string {
+	return filepath.Join(fmt.Sprintf("day%d", day), "input.txt")
+}
+
+// Open resolves the input for day. An explicit path always wins and is the
+// only place looked at; "-" selects standard input.
+func (r *Resolver) Open(day int, explicit string) (*Source, error) {
+	if explicit == Stdin {
+		return &Source{ReadCloser: io.NopCloser(r.Stdin), Name: "stdin"}, nil
+	}
+
+	if explicit != "" {
+		f, err := os.Open(explicit)
+		if err != nil {
+			return nil, &NotFoundError{Day: day, Attempts: []Attempt{
+				{Rule: "-input flag", Path: explicit, Err: err},
+			}}
+		}
+		return &Source{ReadCloser: f, Name: explicit}, nil
+	}
+
+	var attempts []Attempt
+
+	if dir := r.Getenv(EnvDir); dir != "" {
+		path := filepath.Join(dir, FileName(day))
+		f, err := os.Open(path)
+		if err == nil {
+			return &Source{ReadCloser: f, Name: path}, nil
+		}
+		attempts = append(attempts, Attempt{Rule: "$" + EnvDir, Path: path, Err: err})
+	} else {
+		attempts = append(attempts, Attempt{Rule: "$" + EnvDir, Err: errors.New("not set")})
+	}
+
+	if exe, err := r.Executable(); err != nil {
+		attempts = append(attempts, Attempt{Rule: "next to binary", Err: err})
+	} else {
+		path := filepath.Join(filepath.Dir(exe), FileName(day))
+		f, err := os.Open(path)
+		if err == nil {
+			return &Source{ReadCloser: f, Name: path}, nil
+		}
+		attempts = append(attempts, Attempt{Rule: "next to binary", Path: path, Err: err})
+	}
+
+	if err := piped(r.Stdin); err != nil {
+		attempts = append(attempts, Attempt{Rule: "stdin", Err: err})
+	} else {
+		return &Source{ReadCloser: io.NopCloser(r.Stdin), Name: "stdin"}, nil
+	}
+
+	return nil, &NotFoundError{Day: day, Attempts: attempts}
+}
+
+// piped reports why f cannot be read as an input, or nil if it is a pipe or
+// redirected file.
+func piped(f *os.File) error {
+	if f == nil {
+		return errors.New("not available")
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	if fi.Mode()&os.ModeCharDevice != 0 {
+		return errors.New("not a pipe or redirected file")
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package input
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolverOrder(t *testing.T) {
+	root := t.TempDir()
+	write := func(dir, content string) string {
+		path := filepath.Join(root, dir, FileName(1))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	explicit := write("explicit", "explicit")
+	envFile := write("env", "env")
+	exeFile := write("bin", "binary")
+	envDir := filepath.Join(root, "env")
+	exe := filepath.Join(root, "bin", "aoc")
+	noInputs := filepath.Join(root, "missing")
+
+	tests := []struct {
+		name     string
+		explicit string
+		env      string
+		exe      string
+		stdin    string // "pipe", "devnull" or "" for none
+		want     string
+		wantName string
+	}{
+		{"flag beats everything", explicit, envDir, exe, "pipe", "explicit", explicit},
+		{"dash reads stdin", Stdin, envDir, exe, "pipe", "piped", "stdin"},
+		{"env before binary", "", envDir, exe, "pipe", "env", envFile},
+		{"binary when env unset", "", "", exe, "pipe", "binary", exeFile},
+		{"binary when env has no input", "", noInputs, exe, "pipe", "binary", exeFile},
+		{"stdin last", "", noInputs, filepath.Join(noInputs, "aoc"), "pipe", "piped", "stdin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Resolver{
+				Getenv: func(key string) string {
+					if key == EnvDir {
+						return tt.env
+					}
+					return ""
+				},
+				Executable: func() (string, error) { return tt.exe, nil },
+				Stdin:      stdin(t, tt.stdin),
+			}
+
+			src, err := r.Open(1, tt.explicit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer src.Close()
+
+			data, err := io.ReadAll(src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want || src.Name != tt.wantName {
+				t.Errorf("read %q from %v, want %q from %v", data, src.Name, tt.want, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestResolverNotFound(t *testing.T) {
+	root := t.TempDir()
+
+	tests := []struct {
+		name     string
+		explicit string
+		env      string
+		exeErr   error
+		stdin    string
+		want     []string
+	}{
+		{"missing flag path", filepath.Join(root, "nope.txt"), root, nil, "pipe", []string{
+			"-input flag: " + filepath.Join(root, "nope.txt"),
+		}},
+		{"terminal-like stdin", "", root, nil, "devnull", []string{
+			"$" + EnvDir + ": " + filepath.Join(root, FileName(1)),
+			"next to binary: " + filepath.Join(root, "bin", FileName(1)),
+			"stdin: not a pipe or redirected file",
+		}},
+		{"nothing available", "", "", errors.New("no executable"), "", []string{
+			"$" + EnvDir + ": not set",
+			"next to binary: no executable",
+			"stdin: not available",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Resolver{
+				Getenv: func(string) string { return tt.env },
+				Executable: func() (string, error) {
+					return filepath.Join(root, "bin", "aoc"), tt.exeErr
+				},
+				Stdin: stdin(t, tt.stdin),
+			}
+
+			_, err := r.Open(1, tt.explicit)
+			var nf *NotFoundError
+			if !errors.As(err, &nf) {
+				t.Fatalf("got %v, want a NotFoundError", err)
+			}
+			if len(nf.Attempts) != len(tt.want) {
+				t.Errorf("%v attempts, want %v:\n%v", len(nf.Attempts), len(tt.want), err)
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(err.Error(), w) {
+					t.Errorf("error does not mention %q:\n%v", w, err)
+				}
+			}
+		})
+	}
+}
+
+// stdin returns a pipe holding "piped", /dev/null, which is a character
+// device like a terminal, or nil.
+func stdin(t *testing.T, kind string) *os.File {
+	t.Helper()
+
+	switch kind {
+	case "pipe":
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.WriteString("piped")
+		w.Close()
+		t.Cleanup(func() { r.Close() })
+		return r
+	case "devnull":
+		f, err := os.Open(os.DevNull)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { f.Close() })
+		return f
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+// Package ledger records accepted answers so refactors can be checked for
+// regressions. Answers are keyed by day, part and a hash of the input they
+// were produced from, and stored as JSON.
+package ledger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sort"
+)
+
+// DefaultPath is where the aoc command keeps its ledger.
+const DefaultPath = "answers.json"
+
+// Status is the outcome of checking an answer against the ledger.
+type Status string
+
+const (
+	Match    = Status("match")
+	Mismatch = Status("mismatch")
+	Unknown  = Status("unknown")
+)
+
+// Entry is one accepted answer.
+type Entry struct {
+	Day    int    `json:"day"`
+	Part   int    `json:"part"`
+	Input  string `json:"input"`
+	Answer string `json:"answer"`
+}
+
+type key struct {
+	day   int
+	part  int
+	input string
+}
+
+// Ledger is an in-memory view of an answers file.
+type Ledger struct {
+	path    string
+	answers map[key]string
+}
+
+// Hash returns the identifier the ledger uses for an input.
+func Hash(input []byte) string {
+	sum := sha256.Sum256(input)
+	return hex.EncodeToString(sum[:])
+}
+
+// Load reads the ledger at path. A missing file is an empty ledger.
+func Load(path string) (*Ledger, error) {
+	l := &Ledger{path: path, answers: map[key]string{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		l.answers[key{e.Day, e.Part, e.Input}] = e.Answer
+	}
+	return l, nil
+}
+
+// Lookup returns the accepted answer for a day, part and input hash.
+func (l *Ledger) Lookup(day, part int, input string) (string, bool) {
+	a, ok := l.answers[key{day, part, input}]
+	return a, ok
+}
+
+// Check compares answer with the accepted answer, if there is one.
+func (l *Ledger) Check(day, part int, input, answer string) Status {
+	accepted, ok := l.Lookup(day, part, input)
+	switch {
+	case !ok:
+		return Unknown
+	case accepted == answer:
+		return Match
+	default:
+		return Mismatch
+	}
+}
+
+// Accept records answer as the accepted answer, replacing any earlier one.
+func (l *Ledger) Accept(day, part int, input, answer string) {
+	l.answers[key{day, part, input}] = answer
+}
+
+// Entries returns every accepted answer ordered by day, part and input.
+func (l *Ledger) Entries() []Entry {
+	entries := []Entry{}
+	for k, a := range l.answers {
+		entries = append(entries, Entry{Day: k.day, Part: k.part, Input: k.input, Answer: a})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Day != b.Day {
+			return a.Day < b.Day
+		}
+		if a.Part != b.Part {
+			return a.Part < b.Part
+		}
+		return a.Input < b.Input
+	})
+	return entries
+}
+
+// Save writes the ledger back to the file it was loaded from.
+func (l *Ledger) Save() error {
+	data, err := json.MarshalIndent(l.Entries(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(l.path, append(data, '\n'), 0o644)
+}
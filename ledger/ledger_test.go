@@ -0,0 +1,47 @@
+package ledger
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLedgerRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+
+	l, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash := Hash([]byte("A Y\nB X\nC Z"))
+	if got := l.Check(2, 1, hash, "15"); got != Unknown {
+		t.Errorf("empty ledger Check = %v, want %v", got, Unknown)
+	}
+
+	l.Accept(2, 1, hash, "15")
+	if err := l.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		part   int
+		input  string
+		answer string
+		want   Status
+	}{
+		{1, hash, "15", Match},
+		{1, hash, "12", Mismatch},
+		{2, hash, "12", Unknown},
+		{1, Hash([]byte("other input")), "15", Unknown},
+	}
+	for _, tt := range tests {
+		if got := reloaded.Check(2, tt.part, tt.input, tt.answer); got != tt.want {
+			t.Errorf("Check(2, %v, %.8v, %q) = %v, want %v", tt.part, tt.input, tt.answer, got, tt.want)
+		}
+	}
+}
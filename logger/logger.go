@@ -0,0 +1,101 @@
+// Package logger is the leveled diagnostic log the solvers write to instead
+// of stdout. It is silent until a level is set, so answers are the only
+// thing a day prints.
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Level orders how much is logged. Each level includes the ones before it.
+type Level int
+
+const (
+	// Off logs nothing. It is the default.
+	Off Level = iota
+	// Info logs one line per notable result, such as a per-round score.
+	Info
+	// Debug adds intermediate state such as dumps after a simulation.
+	Debug
+	// Trace adds step-by-step output, which can be very large.
+	Trace
+)
+
+var levelNames = []string{"off", "info", "debug", "trace"}
+
+func (l Level) String() string {
+	if l < Off || l > Trace {
+		return fmt.Sprintf("Level(%d)", int(l))
+	}
+	return levelNames[l]
+}
+
+// ParseLevel returns the level called name.
+func ParseLevel(name string) (Level, error) {
+	for i, n := range levelNames {
+		if strings.EqualFold(name, n) {
+			return Level(i), nil
+		}
+	}
+	return Off, fmt.Errorf("unknown log level %q (want one of %v)", name, strings.Join(levelNames, ", "))
+}
+
+var (
+	mu    sync.Mutex
+	level           = Off
+	out   io.Writer = os.Stderr
+)
+
+// SetLevel sets the most detailed level that is written.
+func SetLevel(l Level) {
+	mu.Lock()
+	defer mu.Unlock()
+	level = l
+}
+
+// SetOutput sets where log lines go. The default is stderr.
+func SetOutput(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	out = w
+}
+
+// Enabled reports whether l is written, for callers that want to skip
+// building an expensive message.
+func Enabled(l Level) bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return l != Off && l <= level
+}
+
+// Infof logs at Info.
+func Infof(format string, args ...any) {
+	logf(Info, format, args...)
+}
+
+// Debugf logs at Debug.
+func Debugf(format string, args ...any) {
+	logf(Debug, format, args...)
+}
+
+// Tracef logs at Trace.
+func Tracef(format string, args ...any) {
+	logf(Trace, format, args...)
+}
+
+func logf(l Level, format string, args ...any) {
+	mu.Lock()
+	defer mu.Unlock()
+	if l == Off || l > level {
+		return
+	}
+
+	msg := strings.TrimSuffix(fmt.Sprintf(format, args...), "\n")
+	for _, line := range strings.Split(msg, "\n") {
+		fmt.Fprintf(out, "%v: %v\n", l, line)
+	}
+}
@@ -0,0 +1,46 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestLevels(t *testing.T) {
+	defer SetOutput(os.Stderr)
+	defer SetLevel(Off)
+
+	var buf bytes.Buffer
+	SetOutput(&buf)
+
+	Infof("silent by default")
+	if buf.Len() != 0 {
+		t.Errorf("default level wrote %q", buf.String())
+	}
+
+	SetLevel(Debug)
+	Infof("round %v", 1)
+	Debugf("a\nb\n")
+	Tracef("too detailed")
+
+	want := "info: round 1\ndebug: a\ndebug: b\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+
+	if Enabled(Trace) || !Enabled(Debug) {
+		t.Errorf("Enabled does not match level %v", Debug)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for _, l := range []Level{Off, Info, Debug, Trace} {
+		got, err := ParseLevel(l.String())
+		if err != nil || got != l {
+			t.Errorf("ParseLevel(%q) = %v, %v, want %v", l.String(), got, err, l)
+		}
+	}
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Errorf("ParseLevel(%q) succeeded", "loud")
+	}
+}
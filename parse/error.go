@@ -0,0 +1,62 @@
+// Package parse holds the helpers shared by every day's input parser.
+package parse
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseError reports malformed puzzle input. Line and Col are 1-based and Col
+// counts characters within Text; zero means the position is not known.
+type ParseError struct {
+	Day   int
+	Line  int
+	Col   int
+	Text  string
+	Cause error
+}
+
+func (e *ParseError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "day %v", e.Day)
+	if e.Line > 0 {
+		fmt.Fprintf(&b, ", line %v", e.Line)
+	}
+	if e.Col > 0 {
+		fmt.Fprintf(&b, ", col %v", e.Col)
+	}
+	fmt.Fprintf(&b, ": %v", e.Cause)
+	return b.String()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Cause
+}
+
+// Caret returns the offending line followed by a line with a caret under
+// Col. Tabs in the text are kept so the caret lines up in a terminal. It
+// returns just the text when the column is not known.
+func (e *ParseError) Caret() string {
+	if e.Col <= 0 {
+		return e.Text + "\n"
+	}
+
+	var pad strings.Builder
+	for i, r := range []rune(e.Text) {
+		if i >= e.Col-1 {
+			break
+		}
+		if r == '\t' {
+			pad.WriteRune('\t')
+		} else {
+			pad.WriteRune(' ')
+		}
+	}
+
+	// A caret just past the end of the text points at something missing.
+	for i := len([]rune(e.Text)); i < e.Col-1; i++ {
+		pad.WriteRune(' ')
+	}
+
+	return e.Text + "\n" + pad.String() + "^\n"
+}
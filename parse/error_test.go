@@ -0,0 +1,54 @@
+package parse
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	cause := errors.New("stack 12 does not exist")
+	tests := []struct {
+		name      string
+		err       *ParseError
+		wantError string
+		wantCaret string
+	}{
+		{
+			name:      "line and column",
+			err:       &ParseError{Day: 5, Line: 7, Col: 13, Text: "move 3 from 12 to 3", Cause: cause},
+			wantError: "day 5, line 7, col 13: stack 12 does not exist",
+			wantCaret: "move 3 from 12 to 3\n            ^\n",
+		},
+		{
+			name:      "tabs are kept",
+			err:       &ParseError{Day: 1, Line: 2, Col: 3, Text: "\t\tx", Cause: cause},
+			wantError: "day 1, line 2, col 3: stack 12 does not exist",
+			wantCaret: "\t\tx\n\t\t^\n",
+		},
+		{
+			name:      "past the end",
+			err:       &ParseError{Day: 12, Line: 3, Col: 4, Text: "ab", Cause: cause},
+			wantError: "day 12, line 3, col 4: stack 12 does not exist",
+			wantCaret: "ab\n   ^\n",
+		},
+		{
+			name:      "no position",
+			err:       &ParseError{Day: 11, Cause: cause},
+			wantError: "day 11: stack 12 does not exist",
+			wantCaret: "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.wantError {
+				t.Errorf("Error() = %q, want %q", got, tt.wantError)
+			}
+			if got := tt.err.Caret(); got != tt.wantCaret {
+				t.Errorf("Caret() = %q, want %q", got, tt.wantCaret)
+			}
+			if !errors.Is(tt.err, cause) {
+				t.Errorf("errors.Is(err, cause) = false, want true")
+			}
+		})
+	}
+}
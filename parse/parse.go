@@ -0,0 +1,230 @@
+package parse
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Group is a run of consecutive lines. Line is the 1-based line number of
+// the first one.
+type Group struct {
+	Line  int
+	Lines []string
+}
+
+// EachGroup calls fn for every blank-line separated group in r, including a
+// final group that is not followed by a blank line. It stops at the first
+// error fn returns. Only one group is held in memory at a time.
+func EachGroup(r io.Reader, fn func(g Group) error) error {
+	var g Group
+	lineNum := 0
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+		lineNum++
+
+		if strings.TrimSpace(line) == "" {
+			if len(g.Lines) > 0 {
+				if err := fn(g); err != nil {
+					return err
+				}
+			}
+			g = Group{}
+			continue
+		}
+
+		if len(g.Lines) == 0 {
+			g.Line = lineNum
+		}
+		g.Lines = append(g.Lines, line)
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+
+	if len(g.Lines) > 0 {
+		return fn(g)
+	}
+	return nil
+}
+
+// Groups returns every blank-line separated group in r.
+func Groups(r io.Reader) ([]Group, error) {
+	groups := []Group{}
+	err := EachGroup(r, func(g Group) error {
+		groups = append(groups, g)
+		return nil
+	})
+	return groups, err
+}
+
+// Chunks splits r into groups of exactly n lines. A short final chunk is a
+// *ParseError.
+func Chunks(r io.Reader, n int) ([]Group, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("parse: chunk size must be at least 1, got %v", n)
+	}
+
+	chunks := []Group{}
+	var c Group
+	lineNum := 0
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		lineNum++
+		if len(c.Lines) == 0 {
+			c.Line = lineNum
+		}
+		c.Lines = append(c.Lines, sc.Text())
+
+		if len(c.Lines) == n {
+			chunks = append(chunks, c)
+			c = Group{}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(c.Lines) > 0 {
+		return nil, &ParseError{Line: lineNum, Cause: fmt.Errorf("last group has %v lines, want %v", len(c.Lines), n)}
+	}
+	return chunks, nil
+}
+
+// Columns slices line into fixed-width columns of width characters. The
+// last column is shorter when the line does not fill it.
+func Columns(line string, width int) []string {
+	cols := []string{}
+	if width < 1 {
+		return cols
+	}
+
+	chars := []rune(line)
+	for i := 0; i < len(chars); i += width {
+		end := i + width
+		if end > len(chars) {
+			end = len(chars)
+		}
+		cols = append(cols, string(chars[i:end]))
+	}
+	return cols
+}
+
+var intRX = regexp.MustCompile(`-?[0-9]+`)
+
+// Ints returns every integer in line in order, ignoring anything between
+// them.
+func Ints(line string) []int {
+	ints := []int{}
+	for _, s := range intRX.FindAllString(line, -1) {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			continue
+		}
+		ints = append(ints, n)
+	}
+	return ints
+}
+
+// Record decodes lines matched by a regular expression into a struct. Each
+// field to fill is tagged with the name of a capture group:
+//
+//	type step struct {
+//		Quantity int `re:"quantity"`
+//	}
+//
+// Fields may be string, int, bool or []int; []int fields are filled with
+// Ints. Fields naming a group the expression does not have, or a group that
+// did not take part in the match, are left untouched, so one struct can
+// collect several records.
+type Record struct {
+	re *regexp.Regexp
+}
+
+// NewRecord returns a Record for re.
+func NewRecord(re *regexp.Regexp) *Record {
+	return &Record{re: re}
+}
+
+// Match reports whether line matches the record.
+func (rec *Record) Match(line string) bool {
+	return rec.re.MatchString(line)
+}
+
+// Decode fills the tagged fields of the struct v points to from line.
+// Malformed input is reported as a *ParseError whose Col points at the
+// offending group; the caller fills in Day and Line.
+func (rec *Record) Decode(line string, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("parse: Decode needs a pointer to a struct, got %T", v)
+	}
+
+	m := rec.re.FindStringSubmatchIndex(line)
+	if m == nil {
+		return &ParseError{Col: 1, Text: line, Cause: fmt.Errorf("does not match %v", rec.re)}
+	}
+
+	st := rv.Elem()
+	for i := 0; i < st.NumField(); i++ {
+		f := st.Type().Field(i)
+		name, ok := f.Tag.Lookup("re")
+		if !ok {
+			continue
+		}
+
+		g := rec.re.SubexpIndex(name)
+		if g < 0 {
+			continue
+		}
+
+		start, end := m[2*g], m[2*g+1]
+		if start < 0 {
+			continue
+		}
+
+		if err := setField(st.Field(i), line[start:end]); err != nil {
+			return &ParseError{Col: utf8.RuneCountInString(line[:start]) + 1, Text: line, Cause: err}
+		}
+	}
+
+	return nil
+}
+
+func setField(field reflect.Value, text string) error {
+	if !field.CanSet() {
+		return fmt.Errorf("parse: cannot set unexported field")
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(text)
+	case reflect.Int:
+		n, err := strconv.Atoi(text)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(n))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.Int {
+			return fmt.Errorf("parse: unsupported field type %v", field.Type())
+		}
+		field.Set(reflect.ValueOf(Ints(text)))
+	default:
+		return fmt.Errorf("parse: unsupported field type %v", field.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package parse
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestGroups(t *testing.T) {
+	groups, err := Groups(strings.NewReader("1000\n2000\n\n4000\n\n\n5000\n6000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Group{
+		{Line: 1, Lines: []string{"1000", "2000"}},
+		{Line: 4, Lines: []string{"4000"}},
+		{Line: 7, Lines: []string{"5000", "6000"}},
+	}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("Groups = %+v, want %+v", groups, want)
+	}
+}
+
+func TestChunks(t *testing.T) {
+	chunks, err := Chunks(strings.NewReader("a\nb\nc\nd\n"), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Group{
+		{Line: 1, Lines: []string{"a", "b"}},
+		{Line: 3, Lines: []string{"c", "d"}},
+	}
+	if !reflect.DeepEqual(chunks, want) {
+		t.Errorf("Chunks = %+v, want %+v", chunks, want)
+	}
+
+	_, err = Chunks(strings.NewReader("a\nb\nc\n"), 2)
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Line != 3 {
+		t.Errorf("Chunks with a short final chunk error = %v, want a ParseError on line 3", err)
+	}
+}
+
+func TestColumnsAndInts(t *testing.T) {
+	if got, want := Columns("    [D]    ", 4), []string{"    ", "[D] ", "   "}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Columns = %q, want %q", got, want)
+	}
+
+	if got, want := Ints("move 12 from -3 to 4x5"), []int{12, -3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Ints = %v, want %v", got, want)
+	}
+}
+
+func TestRecord(t *testing.T) {
+	type step struct {
+		Quantity int    `re:"quantity"`
+		From     string `re:"from"`
+		Items    []int  `re:"items"`
+		Ignored  int
+	}
+
+	rec := NewRecord(regexp.MustCompile(`^move (?P<quantity>\S+) from (?P<from>\w+)(?: items (?P<items>.*))?$`))
+
+	var got step
+	if err := rec.Decode("move 3 from a items 1, 2", &got); err != nil {
+		t.Fatal(err)
+	}
+	if want := (step{Quantity: 3, From: "a", Items: []int{1, 2}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode = %+v, want %+v", got, want)
+	}
+
+	err := rec.Decode("move x from a", &got)
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Col != 6 {
+		t.Errorf("Decode with a bad number error = %v, want a ParseError at col 6", err)
+	}
+
+	err = rec.Decode("jump 3", &got)
+	if !errors.As(err, &pe) || pe.Col != 1 {
+		t.Errorf("Decode with no match error = %v, want a ParseError at col 1", err)
+	}
+}
@@ -0,0 +1,70 @@
+package solver
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Command is an extra mode a day offers beyond its two parts, run as
+// "aoc dayN <name> [flags]". It reads the same puzzle input as the parts.
+type Command interface {
+	// Name is the word used to select the command.
+	Name() string
+
+	// Synopsis is a one-line description for usage listings.
+	Synopsis() string
+
+	// SetFlags defines the command's flags on fs.
+	SetFlags(fs *flag.FlagSet)
+
+	// Run executes the command after its flags have been parsed, writing
+	// its report to out.
+	Run(in io.Reader, out io.Writer) error
+}
+
+var commands = map[int]map[string]Command{}
+
+// RegisterCommand makes c available for day. Like Register it is meant to
+// be called from init and panics if the name is taken.
+func RegisterCommand(day int, c Command) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if c == nil {
+		panic(fmt.Sprintf("solver: RegisterCommand command is nil for day %v", day))
+	}
+
+	if commands[day] == nil {
+		commands[day] = map[string]Command{}
+	}
+
+	if _, dup := commands[day][c.Name()]; dup {
+		panic(fmt.Sprintf("solver: RegisterCommand called twice for day %v command %q", day, c.Name()))
+	}
+
+	commands[day][c.Name()] = c
+}
+
+// LookupCommand returns the command called name for day.
+func LookupCommand(day int, name string) (Command, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	c, ok := commands[day][name]
+	return c, ok
+}
+
+// Commands returns the commands registered for day sorted by name.
+func Commands(day int) []Command {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	cmds := []Command{}
+	for _, c := range commands[day] {
+		cmds = append(cmds, c)
+	}
+	sort.Slice(cmds, func(i, j int) bool { return cmds[i].Name() < cmds[j].Name() })
+	return cmds
+}
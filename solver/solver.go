@@ -0,0 +1,73 @@
+// Package solver holds the registry each day's solution adds itself to, so
+// every day and part can be run the same way from the aoc command and tests.
+package solver
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Solver solves both parts of a single day's puzzle. Answers are returned as
+// strings because not every puzzle has a numeric answer.
+type Solver interface {
+	PartOne(r io.Reader) (string, error)
+	PartTwo(r io.Reader) (string, error)
+}
+
+var (
+	mu       sync.RWMutex
+	registry = map[int]Solver{}
+)
+
+// Register makes a solver available for the given day. It is meant to be
+// called from a day package's init and panics if the day is registered twice.
+func Register(day int, s Solver) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if s == nil {
+		panic(fmt.Sprintf("solver: Register solver is nil for day %v", day))
+	}
+
+	if _, dup := registry[day]; dup {
+		panic(fmt.Sprintf("solver: Register called twice for day %v", day))
+	}
+
+	registry[day] = s
+}
+
+// Lookup returns the solver registered for day.
+func Lookup(day int) (Solver, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	s, ok := registry[day]
+	return s, ok
+}
+
+// Days returns every registered day in ascending order.
+func Days() []int {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	days := []int{}
+	for d := range registry {
+		days = append(days, d)
+	}
+	sort.Ints(days)
+	return days
+}
+
+// Solve runs the requested part of s against r.
+func Solve(s Solver, part int, r io.Reader) (string, error) {
+	switch part {
+	case 1:
+		return s.PartOne(r)
+	case 2:
+		return s.PartTwo(r)
+	default:
+		return "", fmt.Errorf("solver: part must be 1 or 2, got %v", part)
+	}
+}
@@ -0,0 +1,52 @@
+// Package solvertest runs table-driven example checks against a solver.
+package solvertest
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/asc521/aoc-2022/solver"
+)
+
+// Case is one expected answer. The input is read from File, relative to the
+// package under test, or taken from Input when File is empty.
+type Case struct {
+	Name  string
+	File  string
+	Input string
+	Part  int
+	Want  string
+}
+
+// Run solves every case with s and reports mismatches.
+func Run(t *testing.T, s solver.Solver, cases []Case) {
+	t.Helper()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			var r io.Reader
+			if tc.File != "" {
+				f, err := os.Open(tc.File)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer f.Close()
+				r = f
+			} else {
+				r = strings.NewReader(tc.Input)
+			}
+
+			got, err := solver.Solve(s, tc.Part, r)
+			if err != nil {
+				t.Fatalf("part %v: unexpected error: %v", tc.Part, err)
+			}
+
+			if got != tc.Want {
+				t.Errorf("part %v = %q, want %q", tc.Part, got, tc.Want)
+			}
+		})
+	}
+}